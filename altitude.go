@@ -0,0 +1,214 @@
+package wx
+
+import (
+	"fmt"
+	"math"
+)
+
+// altitudeUnit is the unit of an altitude. Altitudes are measured in
+// the distance units, but have no lowest valid measurement.
+type altitudeUnit struct {
+	distanceType
+}
+
+// String returns the string representation of the altitude unit.
+func (a altitudeUnit) String() string {
+	return a.distanceType.String()
+}
+
+// definition returns the registry entry of the distance unit without
+// a lowest valid measurement.
+func (a altitudeUnit) definition() (unitDefinition, bool) {
+	def, ok := distanceUnits[a.distanceType]
+	def.min = math.Inf(-1)
+
+	return def, ok
+}
+
+// Altitude is a signed height above a reference level, usually mean
+// sea level, such as a station elevation or a pressure altitude.
+// Unlike a distance, an altitude below the reference level is a valid
+// negative measurement.
+type Altitude Quantity[altitudeUnit]
+
+// NewAltitude creates a new altitude measurement. The altitude is
+// invalid if the unit is not a distance unit or the measurement is
+// NaN.
+func NewAltitude(measurement float64, unit DistanceUnit) Altitude {
+	return Altitude(NewQuantity(measurement, altitudeUnit{unit.distanceType}))
+}
+
+// quantity returns the altitude as a generic quantity.
+func (a Altitude) quantity() Quantity[altitudeUnit] {
+	return Quantity[altitudeUnit](a)
+}
+
+// FT returns the altitude in feet.
+func (a Altitude) FT() float64 {
+	return a.In(Feet)
+}
+
+// M returns the altitude in meters.
+func (a Altitude) M() float64 {
+	return a.In(Meters)
+}
+
+// In returns the altitude in the specified unit.
+func (a Altitude) In(unit DistanceUnit) float64 {
+	return a.quantity().In(altitudeUnit{unit.distanceType})
+}
+
+// To converts the altitude to the specified unit.
+func (a Altitude) To(unit DistanceUnit) Altitude {
+	return Altitude(a.quantity().To(altitudeUnit{unit.distanceType}))
+}
+
+// ToFt converts the altitude to feet.
+func (a Altitude) ToFt() Altitude {
+	return a.To(Feet)
+}
+
+// ToM converts the altitude to meters.
+func (a Altitude) ToM() Altitude {
+	return a.To(Meters)
+}
+
+// String returns the string representation of the altitude.
+func (a Altitude) String() string {
+	if !a.valid {
+		return fmt.Sprintf("invalid %s", a.unit.String())
+	}
+
+	return fmt.Sprintf("%0.2f %s", a.measurement, a.unit.String())
+}
+
+// Valid returns true if the altitude is valid.
+func (a Altitude) Valid() bool {
+	return a.valid
+}
+
+// Unit returns the unit of the altitude.
+func (a Altitude) Unit() DistanceUnit {
+	return DistanceUnit{a.unit.distanceType}
+}
+
+// PressureAltitude returns the pressure altitude for an altimeter
+// setting and field elevation. It is the altitude in the standard
+// atmosphere at which the station pressure is found, computed as the
+// field elevation plus the standard altitude of the altimeter setting.
+//
+// The result is in the same unit as the elevation and is invalid if
+// either input is invalid.
+func PressureAltitude(altimeter Pressure, elevation Altitude) Altitude {
+	m, ok := pressureAltitude(altimeter, elevation)
+	if !ok {
+		return Altitude{valid: false}
+	}
+
+	return NewAltitude(m, Meters).To(elevation.Unit())
+}
+
+// DensityAltitude returns the density altitude for an altimeter
+// setting, field elevation and temperature, assuming dry air. It is
+// the altitude in the standard atmosphere at which the air density
+// equals the density at the field.
+//
+// The result is in the same unit as the elevation and is invalid if
+// any input is invalid.
+func DensityAltitude(altimeter Pressure, elevation Altitude, t Temp) Altitude {
+	if !t.valid {
+		return Altitude{valid: false}
+	}
+
+	return densityAltitude(altimeter, elevation, func(float64) float64 {
+		return t.K()
+	})
+}
+
+// DensityAltitudeWithDewPoint returns the density altitude for an
+// altimeter setting, field elevation, temperature and dew point.
+// Humid air is less dense than dry air at the same temperature, so
+// the moisture is accounted for with the virtual temperature.
+//
+// The result is in the same unit as the elevation and is invalid if
+// any input is invalid.
+func DensityAltitudeWithDewPoint(altimeter Pressure, elevation Altitude, t, dewPoint Temp) Altitude {
+	if !t.valid || !dewPoint.valid {
+		return Altitude{valid: false}
+	}
+
+	e := saturationVaporPressure(dewPoint.C(), HylandWexler, false)
+
+	return densityAltitude(altimeter, elevation, func(hPa float64) float64 {
+		return t.K() / (1 - e/hPa*(1-epsilonMoist))
+	})
+}
+
+// pressureAltitude returns the pressure altitude in meters.
+func pressureAltitude(altimeter Pressure, elevation Altitude) (float64, bool) {
+	if !elevation.valid {
+		return 0, false
+	}
+
+	h, err := StandardPressureAltitude(altimeter)
+	if err != nil {
+		return 0, false
+	}
+
+	return elevation.M() + h.M(), true
+}
+
+// densityAltitude returns the density altitude from the station
+// pressure implied by the altimeter setting and the (virtual)
+// temperature in kelvin that virtualTemp returns for the station
+// pressure in hPa.
+func densityAltitude(altimeter Pressure, elevation Altitude, virtualTemp func(hPa float64) float64) Altitude {
+	pa, ok := pressureAltitude(altimeter, elevation)
+	if !ok {
+		return Altitude{valid: false}
+	}
+
+	station, err := StandardAtmosphere(NewAltitude(pa, Meters))
+	if err != nil {
+		return Altitude{valid: false}
+	}
+
+	p := station.Pressure.Pa()
+	rho := p / (isaGasConstant * virtualTemp(p/100))
+
+	m, ok := standardDensityAltitude(rho)
+	if !ok {
+		return Altitude{valid: false}
+	}
+
+	return NewAltitude(m, Meters).To(elevation.Unit())
+}
+
+// standardDensityAltitude returns the geopotential altitude in
+// meters at which the standard atmosphere has the given density in
+// kg/m³. The density decreases with altitude, so the altitude is
+// found by bisection.
+func standardDensityAltitude(rho float64) (float64, bool) {
+	density := func(h float64) float64 {
+		l := isaLayerAt(h)
+		t := l.tempAt(h)
+
+		return l.pressureAt(h) / (isaGasConstant * t)
+	}
+
+	lo, hi := isaMinAltitude, isaMaxAltitude
+	if rho > density(lo) || rho < density(hi) {
+		return 0, false
+	}
+
+	for i := 0; i < 100 && hi-lo > 1e-6; i++ {
+		mid := (lo + hi) / 2
+		if density(mid) > rho {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2, true
+}
@@ -0,0 +1,152 @@
+package wx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestNewAltitude(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		a     Altitude
+		want  float64
+		unit  DistanceUnit
+		valid bool
+	}{
+		{"above sea level", NewAltitude(5434, Feet), 5434, Feet, true},
+		{"below sea level", NewAltitude(-1407, Feet), -1407, Feet, true},
+		{"converted below sea level", NewAltitude(-1407, Feet).ToM(), -428.8536, Meters, true},
+		{"invalid unit", NewAltitude(10, DistanceUnit{}), 0, DistanceUnit{}, false},
+		{"not a number", NewAltitude(math.NaN(), Meters), math.NaN(), Meters, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.a.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, tc.a.Valid())
+			}
+
+			if tc.a.Unit() != tc.unit {
+				t.Errorf("expected unit %v, got %v", tc.unit, tc.a.Unit())
+			}
+
+			if tc.valid && !tests.CloseEnough(tc.a.In(tc.unit), tc.want, 1e-4) {
+				t.Errorf("expected %v, got %v", tc.want, tc.a.In(tc.unit))
+			}
+		})
+	}
+
+	if got := NewAltitude(-100, Meters).WithUncertainty(1).ToFt().Uncertainty(); !tests.CloseEnough(got, feetPerMeter, tests.Tolerance) {
+		t.Errorf("expected an uncertainty of %v ft, got %v", feetPerMeter, got)
+	}
+
+	if got, err := ParseAltitude("-1407 ft"); err != nil || got != NewAltitude(-1407, Feet) {
+		t.Errorf("expected -1407 ft, got %v, %v", got, err)
+	}
+
+	if got := NewAltitude(-100, Meters).Convert(Aviation); !got.Valid() || !tests.CloseEnough(got.FT(), -328.0840, 1e-4) || got.Unit() != Feet {
+		t.Errorf("expected -328.08 ft, got %v", got)
+	}
+}
+
+func TestPressureAltitude(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		altimeter Pressure
+		elevation Altitude
+		want      float64
+		unit      DistanceUnit
+		valid     bool
+	}{
+		{"standard", NewPressure(1013.25, HPa), NewAltitude(0, Meters), 0, Meters, true},
+		{"denver", NewPressure(30.12, InHg), NewAltitude(5434, Feet), 5250.6749, Feet, true},
+		{"low pressure", NewPressure(29.42, InHg), NewAltitude(1000, Feet), 1466.7452, Feet, true},
+		{"below sea level", NewPressure(1030, HPa), NewAltitude(-100, Meters), -238.5067, Meters, true},
+		{"high pressure at a sea level field", NewPressure(30.42, InHg), NewAltitude(10, Feet), -448.1867, Feet, true},
+		{"invalid pressure", Pressure{}, NewAltitude(0, Meters), 0, DistanceUnit{}, false},
+		{"missing elevation", NewPressure(1013.25, HPa), Altitude{}, 0, DistanceUnit{}, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			pa := PressureAltitude(tc.altimeter, tc.elevation)
+			if pa.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, pa.Valid())
+			}
+
+			if pa.Unit() != tc.unit {
+				t.Fatalf("expected unit %v, got %v", tc.unit, pa.Unit())
+			}
+
+			got := pa.M()
+			if tc.unit == Feet {
+				got = pa.FT()
+			}
+
+			if tc.unit != (DistanceUnit{}) && !tests.CloseEnough(got, tc.want, 0.1) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDensityAltitude(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		altimeter Pressure
+		elevation Altitude
+		temp      Temp
+		want      float64
+		valid     bool
+	}{
+		{"standard", NewPressure(1013.25, HPa), NewAltitude(5000, Feet), NewTemp(15-0.0065*1524, Celsius), 5000, true},
+		{"denver hot day", NewPressure(30.12, InHg), NewAltitude(5434, Feet), NewTemp(30, Celsius), 8104.067, true},
+		{"cold day", NewPressure(29.92126, InHg), NewAltitude(0, Feet), NewTemp(-15, Celsius), -3806.1181, true},
+		{"cold high pressure day at a sea level field", NewPressure(30.42, InHg), NewAltitude(10, Feet), NewTemp(-10, Celsius), -3700.5064, true},
+		{"invalid temp", NewPressure(29.92, InHg), NewAltitude(0, Feet), Temp{}, 0, false},
+		{"below sea level", NewPressure(30.12, InHg), NewAltitude(-1407, Feet), NewTemp(35, Celsius), 339.5529, true},
+		{"missing elevation", NewPressure(29.92, InHg), Altitude{}, NewTemp(15, Celsius), 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			da := DensityAltitude(tc.altimeter, tc.elevation, tc.temp)
+			if da.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, da.Valid())
+			}
+
+			if !tests.CloseEnough(da.FT(), tc.want, 0.1) {
+				t.Errorf("expected %v, got %v", tc.want, da.FT())
+			}
+		})
+	}
+}
+
+func TestDensityAltitudeWithDewPoint(t *testing.T) {
+	t.Parallel()
+
+	alt, elev, temp := NewPressure(30.12, InHg), NewAltitude(5434, Feet), NewTemp(30, Celsius)
+
+	dry := DensityAltitude(alt, elev, temp)
+	humid := DensityAltitudeWithDewPoint(alt, elev, temp, NewTemp(15, Celsius))
+	if !tests.CloseEnough(humid.FT(), 8353.889, 0.1) {
+		t.Errorf("expected 8353.889, got %v", humid.FT())
+	}
+
+	// Moist air is less dense, so the density altitude is higher.
+	if humid.FT() <= dry.FT() {
+		t.Errorf("expected humid %v above dry %v", humid.FT(), dry.FT())
+	}
+
+	if da := DensityAltitudeWithDewPoint(alt, elev, temp, Temp{}); da.Valid() {
+		t.Errorf("expected invalid density altitude, got %v", da)
+	}
+}
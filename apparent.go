@@ -0,0 +1,178 @@
+package wx
+
+import "math"
+
+const (
+	// Minimum temperature in Fahrenheit for the NWS heat index.
+	heatIndexMinF = 80.0
+	// Maximum temperature in Fahrenheit for the NWS wind chill.
+	windChillMaxF = 50.0
+	// Minimum wind speed in miles per hour for the NWS wind chill.
+	windChillMinMph = 3.0
+	// Minimum temperature in Celsius for the humidex.
+	humidexMinC = 20.0
+	// Minimum and maximum temperatures in Celsius for the apparent
+	// temperature, the range of its vapor pressure formula.
+	apparentTempMinC = -40.0
+	apparentTempMaxC = 50.0
+)
+
+// HeatIndex returns the NWS heat index for a temperature and
+// relative humidity using the Rothfusz regression with the
+// Steadman low and high humidity adjustments.
+//
+// The heat index is only defined at or above 80 °F. Below that the
+// air temperature is returned with an error. The result is in the
+// same unit as t.
+func HeatIndex(t Temp, rh Humidity) (Temp, error) {
+	if !t.valid || !rh.valid {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature or humidity", "heat index")
+	}
+
+	f := t.ToF()
+	if f.measurement < heatIndexMinF {
+		return t, NewWxErrKind(ErrOutOfDomain, "temperature below 80 °F", "heat index")
+	}
+
+	hi := func(x []float64) float64 {
+		return heatIndexF(x[0], x[1])
+	}
+
+	x := []float64{f.measurement, rh.percent}
+	sigma := []float64{f.uncertainty, rh.uncertainty}
+
+	return NewTemp(hi(x), Fahrenheit).WithUncertainty(propagate(hi, x, sigma)).To(t.unit), nil
+}
+
+// heatIndexF returns the NWS heat index in Fahrenheit.
+func heatIndexF(t, rh float64) float64 {
+	// Steadman's simple formula is used when the result is
+	// below 80 °F.
+	hi := 0.5 * (t + 61.0 + (t-68.0)*1.2 + rh*0.094)
+	if (hi+t)/2 < heatIndexMinF {
+		return hi
+	}
+
+	hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+		0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+		0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+
+	switch {
+	case rh < 13 && t >= 80 && t <= 112:
+		hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case rh > 85 && t >= 80 && t <= 87:
+		hi += (rh - 85) / 10 * (87 - t) / 5
+	}
+
+	return hi
+}
+
+// WindChill returns the NWS/MSC (2001) wind chill temperature for
+// a temperature and wind speed measured at 10 m.
+//
+// The wind chill is only defined at or below 50 °F with wind speeds
+// of at least 3 mph. Outside of that domain the air temperature is
+// returned with an error. The result is in the same unit as t.
+func WindChill(t Temp, v Velocity) (Temp, error) {
+	if !t.valid || !v.valid {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature or wind speed", "wind chill")
+	}
+
+	f, mph := t.ToF(), v.ToMph()
+	if f.measurement > windChillMaxF {
+		return t, NewWxErrKind(ErrOutOfDomain, "temperature above 50 °F", "wind chill")
+	}
+
+	if mph.measurement < windChillMinMph {
+		return t, NewWxErrKind(ErrOutOfDomain, "wind speed below 3 mph", "wind chill")
+	}
+
+	wc := func(x []float64) float64 {
+		return windChillF(x[0], x[1])
+	}
+
+	x := []float64{f.measurement, mph.measurement}
+	sigma := []float64{f.uncertainty, mph.uncertainty}
+
+	return NewTemp(wc(x), Fahrenheit).WithUncertainty(propagate(wc, x, sigma)).To(t.unit), nil
+}
+
+// windChillF returns the NWS/MSC wind chill in Fahrenheit for a
+// temperature in Fahrenheit and wind speed in miles per hour.
+func windChillF(t, mph float64) float64 {
+	w := math.Pow(mph, 0.16)
+	return 35.74 + 0.6215*t - 35.75*w + 0.4275*t*w
+}
+
+// Humidex returns the Meteorological Service of Canada humidex for
+// a temperature and relative humidity.
+//
+// The humidex is only reported at or above 20 °C. Below that the
+// air temperature is returned with an error. The result is in the
+// same unit as t.
+func Humidex(t Temp, rh Humidity) (Temp, error) {
+	if !t.valid || !rh.valid {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature or humidity", "humidex")
+	}
+
+	c := t.ToC()
+	if c.measurement < humidexMinC {
+		return t, NewWxErrKind(ErrOutOfDomain, "temperature below 20 °C", "humidex")
+	}
+
+	h := func(x []float64) float64 {
+		return humidexC(x[0], x[1])
+	}
+
+	x := []float64{c.measurement, rh.percent}
+	sigma := []float64{c.uncertainty, rh.uncertainty}
+
+	return NewTemp(h(x), Celsius).WithUncertainty(propagate(h, x, sigma)).To(t.unit), nil
+}
+
+// humidexC returns the humidex in Celsius for a temperature in
+// Celsius and relative humidity in percent.
+func humidexC(t, rh float64) float64 {
+	// Vapor pressure in hPa using the saturation vapor pressure
+	// formula of the humidex definition.
+	e := rh / 100 * 6.11 * math.Exp(5417.7530*(1/273.16-1/(t-absoluteZeroC)))
+
+	return t + 0.5555*(e-10)
+}
+
+// ApparentTemp returns the Australian Bureau of Meteorology apparent
+// temperature (Steadman, 1994) for a temperature, relative humidity
+// and wind speed measured at 10 m, without the effect of radiation.
+//
+// The apparent temperature is only defined from -40 °C to 50 °C, the
+// range of its vapor pressure formula. Outside of that the air
+// temperature is returned with an error. The result is in the same
+// unit as t.
+func ApparentTemp(t Temp, rh Humidity, v Velocity) (Temp, error) {
+	if !t.valid || !rh.valid || !v.valid {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature, humidity or wind speed", "apparent temperature")
+	}
+
+	c, mps := t.ToC(), v.ToMps()
+	if c.measurement < apparentTempMinC || c.measurement > apparentTempMaxC {
+		return t, NewWxErrKind(ErrOutOfDomain, "temperature outside of -40 °C to 50 °C", "apparent temperature")
+	}
+
+	at := func(x []float64) float64 {
+		return apparentTempC(x[0], x[1], x[2])
+	}
+
+	x := []float64{c.measurement, rh.percent, mps.measurement}
+	sigma := []float64{c.uncertainty, rh.uncertainty, mps.uncertainty}
+
+	return NewTemp(at(x), Celsius).WithUncertainty(propagate(at, x, sigma)).To(t.unit), nil
+}
+
+// apparentTempC returns the apparent temperature in Celsius for a
+// temperature in Celsius, relative humidity in percent and wind speed
+// in meters per second.
+func apparentTempC(t, rh, mps float64) float64 {
+	e := rh / 100 * 6.105 * math.Exp(17.27*t/(237.7+t))
+
+	return t + 0.33*e - 0.70*mps - 4.00
+}
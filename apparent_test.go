@@ -0,0 +1,150 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestHeatIndex(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		rh    Humidity
+		want  float64
+		unit  TempUnit
+		isErr bool
+	}{
+		{"moderate", NewTemp(90, Fahrenheit), NewHumidity(50), 94.5969, Fahrenheit, false},
+		{"hot", NewTemp(100, Fahrenheit), NewHumidity(40), 109.2556, Fahrenheit, false},
+		{"low humidity adjustment", NewTemp(100, Fahrenheit), NewHumidity(10), 94.1225, Fahrenheit, false},
+		{"high humidity adjustment", NewTemp(84, Fahrenheit), NewHumidity(90), 98.3425, Fahrenheit, false},
+		{"simple formula", NewTemp(80, Fahrenheit), NewHumidity(20), 78.64, Fahrenheit, false},
+		{"celsius", NewTemp(35, Celsius), NewHumidity(40), 37.2164, Celsius, false},
+		{"too cold", NewTemp(70, Fahrenheit), NewHumidity(50), 70, Fahrenheit, true},
+		{"invalid humidity", NewTemp(90, Fahrenheit), NewHumidity(-1), 0, TempUnit{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			hi, err := HeatIndex(tc.temp, tc.rh)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if hi.Unit() != tc.unit {
+				t.Errorf("expected unit %v, got %v", tc.unit, hi.Unit())
+			}
+
+			got := hi.F()
+			if tc.unit == Celsius {
+				got = hi.C()
+			}
+
+			if !tests.CloseEnough(got, tc.want, 0.1) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWindChill(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		wind  Velocity
+		want  float64
+		isErr bool
+	}{
+		{"cold", NewTemp(0, Fahrenheit), NewVelocity(15, Mph), -19.3980, false},
+		{"very cold", NewTemp(-10, Fahrenheit), NewVelocity(30, Mph), -39.4467, false},
+		{"knots", NewTemp(0, Fahrenheit), NewVelocity(15/1.150779, Kts), -19.3980, false},
+		{"too warm", NewTemp(60, Fahrenheit), NewVelocity(15, Mph), 60, true},
+		{"too calm", NewTemp(0, Fahrenheit), NewVelocity(2, Mph), 0, true},
+		{"invalid wind", NewTemp(0, Fahrenheit), NewVelocity(-1, Mph), 0, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			wc, err := WindChill(tc.temp, tc.wind)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if !tests.CloseEnough(wc.F(), tc.want, 1e-3) {
+				t.Errorf("expected %v, got %v", tc.want, wc.F())
+			}
+		})
+	}
+}
+
+func TestHumidex(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		rh    Humidity
+		want  float64
+		isErr bool
+	}{
+		{"humid", NewTemp(30, Celsius), NewHumidity(70), 41.3475, false},
+		{"kelvin", NewTemp(303.15, Kelvin), NewHumidity(70), 41.3475, false},
+		{"too cold", NewTemp(15, Celsius), NewHumidity(70), 15, true},
+		{"invalid humidity", NewTemp(30, Celsius), NewHumidity(101), 0, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := Humidex(tc.temp, tc.rh)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if h.Unit() != tc.temp.Unit() && err == nil {
+				t.Errorf("expected unit %v, got %v", tc.temp.Unit(), h.Unit())
+			}
+
+			if !tests.CloseEnough(h.C(), tc.want, 1e-3) {
+				t.Errorf("expected %v, got %v", tc.want, h.C())
+			}
+		})
+	}
+}
+
+func TestApparentTemp(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		rh    Humidity
+		wind  Velocity
+		want  float64
+		isErr bool
+	}{
+		{"mild", NewTemp(25, Celsius), NewHumidity(50), NewVelocity(2, Mps), 24.8112, false},
+		{"calm", NewTemp(25, Celsius), NewHumidity(50), NewVelocity(0, Mps), 26.2112, false},
+		{"fahrenheit", NewTemp(77, Fahrenheit), NewHumidity(50), NewVelocity(2, Mps), 24.8112, false},
+		{"too hot", NewTemp(55, Celsius), NewHumidity(50), NewVelocity(2, Mps), 55, true},
+		{"too cold", NewTemp(-45, Celsius), NewHumidity(50), NewVelocity(2, Mps), -45, true},
+		{"invalid wind", NewTemp(25, Celsius), NewHumidity(50), NewVelocity(-2, Mps), 0, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			at, err := ApparentTemp(tc.temp, tc.rh, tc.wind)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if !tests.CloseEnough(at.C(), tc.want, 1e-3) {
+				t.Errorf("expected %v, got %v", tc.want, at.C())
+			}
+		})
+	}
+}
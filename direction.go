@@ -4,6 +4,8 @@ package wx
 // The value is between 0 and 359.9 degrees and is the
 // direction the wind is coming from, not the direction
 // the wind is blowing to in degrees from true north.
+// The magnetic package converts directions between true
+// and magnetic north.
 type WindDirection struct {
 	degrees Degrees
 }
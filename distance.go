@@ -31,26 +31,12 @@ const (
 	meters
 	statuteMiles
 	parsec
+	furlongs
 )
 
 // String returns the string representation of the distance unit.
 func (d distanceType) String() string {
-	switch d {
-	case feet:
-		return "ft"
-	case kilometers:
-		return "km"
-	case nauticalMiles:
-		return "NM"
-	case meters:
-		return "m"
-	case statuteMiles:
-		return "SM"
-	case parsec:
-		return "pc"
-	}
-
-	return ""
+	return distanceUnits[d].symbol
 }
 
 // DistanceUnit represents a unit of distance.
@@ -71,6 +57,8 @@ var (
 	StatuteMiles = DistanceUnit{statuteMiles}
 	// Parsec represents a distance in parsecs.
 	Parsec = DistanceUnit{parsec}
+	// Furlongs represents a distance in furlongs.
+	Furlongs = DistanceUnit{furlongs}
 )
 
 const (
@@ -78,44 +66,50 @@ const (
 	feetPerStatuteMile  = 5_280.0
 	feetPerMeter        = 3.280839895
 	metersPerParsec     = 3.08567758e16
+	feetPerFurlong      = 660.0
 )
 
+// distanceUnits is the registry of distance units. The base unit is
+// the meter.
+var distanceUnits = map[distanceType]unitDefinition{
+	feet:          {symbol: "ft", scale: 1 / feetPerMeter},
+	kilometers:    {symbol: "km", scale: 1000},
+	nauticalMiles: {symbol: "NM", scale: feetPerNauticalMile / feetPerMeter},
+	meters:        {symbol: "m", scale: 1},
+	statuteMiles:  {symbol: "SM", scale: feetPerStatuteMile / feetPerMeter},
+	parsec:        {symbol: "pc", scale: metersPerParsec},
+	furlongs:      {symbol: "fur", scale: feetPerFurlong / feetPerMeter},
+}
+
 // String returns the string representation of the distance unit.
 func (d DistanceUnit) String() string {
 	return d.distanceType.String()
 }
 
+// definition returns the registry entry of the distance unit.
+func (d DistanceUnit) definition() (unitDefinition, bool) {
+	def, ok := distanceUnits[d.distanceType]
+	return def, ok
+}
+
 // Distance represents a distance measurement.
-type Distance struct {
-	measurement float64
-	unit        DistanceUnit
-	valid       bool
+type Distance Quantity[DistanceUnit]
+
+// Quantity returns the distance as a generic quantity.
+func (d Distance) Quantity() Quantity[DistanceUnit] {
+	return Quantity[DistanceUnit](d)
 }
 
 // NewDistance creates a new distance measurement.
 func NewDistance(measurement float64, unit DistanceUnit) Distance {
-	switch unit.distanceType {
-	case feet:
-		unit = Feet
-	case kilometers:
-		unit = Kilometers
-	case nauticalMiles:
-		unit = NauticalMiles
-	case meters:
-		unit = Meters
-	case statuteMiles:
-		unit = StatuteMiles
-	case parsec:
-		unit = Parsec
-	default:
-		return Distance{valid: false}
-	}
+	return Distance(NewQuantity(measurement, unit))
+}
 
-	return Distance{
-		measurement: measurement,
-		unit:        unit,
-		valid:       measurement >= 0.0,
-	}
+// NewDistanceE creates a new distance like NewDistance, but returns an error
+// describing why the distance is invalid, such as a RangeError for a
+// negative distance.
+func NewDistanceE(measurement float64, unit DistanceUnit) (Distance, error) {
+	return NewDistance(measurement, unit), checkQuantity(measurement, unit, "distance")
 }
 
 // Abs returns the absolute value of the distance.
@@ -126,10 +120,11 @@ func (d Distance) Abs() Distance {
 // Add returns the sum of two distances.
 func (d Distance) Add(d2 Distance) Distance {
 	// Convert d2 to the same unit as d.
-	d2 = d2.to(d.unit)
+	d2 = d2.To(d.unit)
 
 	// Add the measurements.
-	return NewDistance(d.measurement+d2.measurement, d.unit)
+	return NewDistance(d.measurement+d2.measurement, d.unit).
+		WithUncertainty(addUncertainties(d.uncertainty, d2.uncertainty))
 }
 
 // Sub returns the difference of two distances.
@@ -151,131 +146,51 @@ func (d Distance) Add(d2 Distance) Distance {
 //	}
 func (d Distance) Sub(d2 Distance) Distance {
 	// Convert d2 to the same unit as d.
-	d2 = d2.to(d.unit)
+	d2 = d2.To(d.unit)
 
 	// Subtract the measurements.
-
-	return NewDistance(d.measurement-d2.measurement, d.unit)
+	return NewDistance(d.measurement-d2.measurement, d.unit).
+		WithUncertainty(addUncertainties(d.uncertainty, d2.uncertainty))
 }
 
 // FT returns the distance in feet.
 func (d Distance) FT() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement
-	case kilometers:
-		return d.measurement * feetPerMeter * 1000.0
-	case nauticalMiles:
-		return d.measurement * feetPerNauticalMile
-	case meters:
-		return d.measurement * feetPerMeter
-	case statuteMiles:
-		return d.measurement * feetPerStatuteMile
-	case parsec:
-		return d.measurement * metersPerParsec * feetPerMeter
-	}
-
-	return 0.0
+	return d.In(Feet)
 }
 
 // KM returns the distance in kilometers.
 func (d Distance) KM() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement / feetPerMeter / 1000.0
-	case kilometers:
-		return d.measurement
-	case nauticalMiles:
-		return d.measurement * feetPerNauticalMile / feetPerMeter / 1000.0
-	case meters:
-		return d.measurement / 1000.0
-	case statuteMiles:
-		return d.measurement * feetPerStatuteMile / feetPerMeter / 1000.0
-	case parsec:
-		return d.measurement * metersPerParsec / 1000.0
-	}
-
-	return 0.0
+	return d.In(Kilometers)
 }
 
 // NM returns the distance in nautical miles.
 func (d Distance) NM() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement / feetPerNauticalMile
-	case kilometers:
-		return d.measurement * feetPerMeter * 1000.0 / feetPerNauticalMile
-	case nauticalMiles:
-		return d.measurement
-	case meters:
-		return d.measurement / feetPerMeter / feetPerNauticalMile
-	case statuteMiles:
-		return d.measurement * feetPerStatuteMile / feetPerMeter / feetPerNauticalMile
-	case parsec:
-		return d.measurement * metersPerParsec / feetPerMeter / feetPerNauticalMile
-	}
-
-	return 0.0
+	return d.In(NauticalMiles)
 }
 
 // M returns the distance in meters.
 func (d Distance) M() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement / feetPerMeter
-	case kilometers:
-		return d.measurement * 1000.0
-	case nauticalMiles:
-		return d.measurement * feetPerNauticalMile / feetPerMeter
-	case meters:
-		return d.measurement
-	case statuteMiles:
-		return d.measurement * feetPerStatuteMile / feetPerMeter
-	case parsec:
-		return d.measurement * metersPerParsec
-	}
-
-	return 0.0
+	return d.In(Meters)
 }
 
 // SM returns the distance in statute miles.
 func (d Distance) SM() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement / feetPerStatuteMile
-	case kilometers:
-		return d.measurement * feetPerMeter * 1000.0 / feetPerStatuteMile
-	case nauticalMiles:
-		return d.measurement * feetPerNauticalMile / feetPerMeter / feetPerStatuteMile
-	case meters:
-		return d.measurement / feetPerMeter / feetPerStatuteMile
-	case statuteMiles:
-		return d.measurement
-	case parsec:
-		return d.measurement * metersPerParsec / feetPerMeter / feetPerStatuteMile
-	}
-
-	return 0.0
+	return d.In(StatuteMiles)
 }
 
 // Parsec returns the distance in parsecs.
 func (d Distance) Parsec() float64 {
-	switch d.unit.distanceType {
-	case feet:
-		return d.measurement / feetPerMeter / metersPerParsec
-	case kilometers:
-		return d.measurement / metersPerParsec * 1000.0
-	case nauticalMiles:
-		return d.measurement / feetPerNauticalMile * feetPerMeter / metersPerParsec
-	case meters:
-		return d.measurement / metersPerParsec
-	case statuteMiles:
-		return d.measurement / feetPerStatuteMile / metersPerParsec
-	case parsec:
-		return d.measurement
-	}
+	return d.In(Parsec)
+}
 
-	return 0.0
+// In returns the distance in the specified unit.
+func (d Distance) In(unit DistanceUnit) float64 {
+	return d.Quantity().In(unit)
+}
+
+// To converts the distance to the specified unit.
+func (d Distance) To(unit DistanceUnit) Distance {
+	return Distance(d.Quantity().To(unit))
 }
 
 // String returns the string representation of the distance.
@@ -290,57 +205,42 @@ func (d Distance) String() string {
 		d.unit.String())
 }
 
-// to converts a distance to the specified unit.
-func (d Distance) to(unit DistanceUnit) Distance {
-	switch unit.distanceType {
-	case feet:
-		return d.ToFt()
-	case kilometers:
-		return d.ToKM()
-	case nauticalMiles:
-		return d.ToNM()
-	case meters:
-		return d.ToM()
-	case statuteMiles:
-		return d.ToSM()
-	case parsec:
-		return d.ToPc()
-	}
-
-	return Distance{valid: false}
-}
-
 // ToFt converts the distance to feet.
 func (d Distance) ToFt() Distance {
-	return NewDistance(d.FT(), Feet)
+	return d.To(Feet)
 }
 
 // ToKM converts the distance to kilometers.
 func (d Distance) ToKM() Distance {
-	return NewDistance(d.KM(), Kilometers)
+	return d.To(Kilometers)
 }
 
 // ToNM converts the distance to nautical miles.
 func (d Distance) ToNM() Distance {
-	return NewDistance(d.NM(), NauticalMiles)
+	return d.To(NauticalMiles)
 }
 
 // ToM converts the distance to meters.
 func (d Distance) ToM() Distance {
-	return NewDistance(d.M(), Meters)
+	return d.To(Meters)
 }
 
 // ToSM converts the distance to statute miles.
 func (d Distance) ToSM() Distance {
-	return NewDistance(d.SM(), StatuteMiles)
+	return d.To(StatuteMiles)
 }
 
 // ToPc converts the distance to parsecs.
 func (d Distance) ToPc() Distance {
-	return NewDistance(d.Parsec(), Parsec)
+	return d.To(Parsec)
 }
 
 // Valid returns true if the distance is valid.
 func (d Distance) Valid() bool {
 	return d.valid
 }
+
+// Unit returns the unit of the distance.
+func (d Distance) Unit() DistanceUnit {
+	return d.unit
+}
@@ -558,3 +558,29 @@ func TestDistanceSub(t *testing.T) {
 		})
 	}
 }
+
+func TestDistanceUnit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		unit     DistanceUnit
+		expected DistanceUnit
+	}{
+		{"feet", Feet, Feet},
+		{"kilometers", Kilometers, Kilometers},
+		{"nautical miles", NauticalMiles, NauticalMiles},
+		{"meters", Meters, Meters},
+		{"statute miles", StatuteMiles, StatuteMiles},
+		{"parsec", Parsec, Parsec},
+		{"invalid", DistanceUnit{99}, DistanceUnit{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := NewDistance(1.0, test.unit).Unit(); got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
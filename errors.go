@@ -1,16 +1,109 @@
 package wx
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+)
 
+// Kinds of errors. Errors returned by the package can be matched
+// against these with errors.Is, e.g.
+//
+//	if errors.Is(err, wx.ErrOutOfDomain) {
+//		// The formula does not apply to the inputs.
+//	}
+var (
+	// ErrInvalidUnit is a unit that is not registered or not
+	// recognized.
+	ErrInvalidUnit = errors.New("invalid unit")
+	// ErrInvalidMeasurement is a measurement that is invalid, such
+	// as an argument that was created invalid.
+	ErrInvalidMeasurement = errors.New("invalid measurement")
+	// ErrBelowAbsoluteZero is a temperature below absolute zero.
+	ErrBelowAbsoluteZero = errors.New("below absolute zero")
+	// ErrNegativeMagnitude is a negative distance, pressure or
+	// velocity.
+	ErrNegativeMagnitude = errors.New("negative magnitude")
+	// ErrOutOfDomain is an input outside the range a formula or
+	// model is defined for.
+	ErrOutOfDomain = errors.New("out of domain")
+	// ErrParse is text that could not be parsed.
+	ErrParse = errors.New("parse failure")
+)
+
+// Error is an error in a context, such as the calculation or parser
+// that failed. It wraps the kind of the error, if known, for
+// errors.Is.
 type Error struct {
 	err     string
 	context string
+	kind    error
 }
 
+// NewWxErr creates a new error in a context.
 func NewWxErr(err string, context string) Error {
-	return Error{err, context}
+	return Error{err: err, context: context}
+}
+
+// NewWxErrKind creates a new error of a kind, such as ErrOutOfDomain,
+// in a context.
+func NewWxErrKind(kind error, err string, context string) Error {
+	return Error{err: err, context: context, kind: kind}
 }
 
 func (e Error) Error() string {
-	return strings.ToLower(e.context + ": " + e.err)
+	return e.context + ": " + e.err
+}
+
+// Context returns the context of the error.
+func (e Error) Context() string {
+	return e.context
+}
+
+// Unwrap returns the kind of the error, or nil if the kind is not
+// known.
+func (e Error) Unwrap() error {
+	return e.kind
+}
+
+// RangeError is a measurement below the lowest valid measurement of
+// its unit. It wraps ErrBelowAbsoluteZero for temperatures and
+// ErrNegativeMagnitude otherwise.
+type RangeError struct {
+	// Measurement is the rejected measurement.
+	Measurement float64
+	// Min is the lowest valid measurement in the unit.
+	Min float64
+	// Unit is the symbol of the unit.
+	Unit string
+	// Err is the kind of the error.
+	Err error
+}
+
+func (e RangeError) Error() string {
+	return fmt.Sprintf("%g %s: %v (minimum %g %s)", e.Measurement, e.Unit, e.Err, e.Min, e.Unit)
+}
+
+// Unwrap returns the kind of the error.
+func (e RangeError) Unwrap() error {
+	return e.Err
+}
+
+// checkQuantity returns an error describing why a quantity with the
+// measurement and unit is invalid, or nil if it is valid.
+func checkQuantity[D Dimension](measurement float64, unit D, context string) error {
+	def, ok := unit.definition()
+	if !ok {
+		return NewWxErrKind(ErrInvalidUnit, "invalid unit", context)
+	}
+
+	if measurement >= def.min {
+		return nil
+	}
+
+	kind := ErrNegativeMagnitude
+	if _, ok := interface{}(unit).(TempUnit); ok {
+		kind = ErrBelowAbsoluteZero
+	}
+
+	return RangeError{Measurement: measurement, Min: def.min, Unit: def.symbol, Err: kind}
 }
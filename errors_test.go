@@ -1,6 +1,9 @@
 package wx
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestNewWxErr(t *testing.T) {
 	err := "test error"
@@ -16,8 +19,108 @@ func TestError_Error(t *testing.T) {
 	err := "test error"
 	context := "test context"
 	expected := "test context: test error"
-	actual := Error{err, context}.Error()
+	actual := Error{err: err, context: context}.Error()
 	if expected != actual {
 		t.Errorf("expected %v, got %v", expected, actual)
 	}
 }
+
+func TestError_Case(t *testing.T) {
+	t.Parallel()
+
+	// Messages keep their case, e.g. unit symbols and report codes.
+	_, err := ParsePressure("29.92 InchesHg")
+
+	if expected := `parse pressure: unknown unit "InchesHg"`; err == nil || err.Error() != expected {
+		t.Errorf("expected %v, got %v", expected, err)
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	t.Parallel()
+
+	_, heatIndexErr := HeatIndex(NewTemp(20, Celsius), NewHumidity(50))
+
+	tt := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"kind", NewWxErrKind(ErrOutOfDomain, "too cold", "test"), ErrOutOfDomain},
+		{"unknown unit", func() error { _, err := ParseVelocity("12 furlongs"); return err }(), ErrInvalidUnit},
+		{"parse failure", func() error { _, err := ParseVelocity("twelve kts"); return err }(), ErrParse},
+		{"below absolute zero", func() error { _, err := ParseTemp("-300 °C"); return err }(), ErrBelowAbsoluteZero},
+		{"negative distance", func() error { _, err := NewDistanceE(-1, Feet); return err }(), ErrNegativeMagnitude},
+		{"heat index", heatIndexErr, ErrOutOfDomain},
+		{"standard atmosphere", func() error { _, err := StandardAtmosphere(NewAltitude(100, Kilometers)); return err }(), ErrOutOfDomain},
+		{"no runway ends", func() error { _, _, err := BestRunway(nil, NewCalmWind(Kts)); return err }(), ErrInvalidMeasurement},
+		{"no wind observations", func() error { _, err := AverageWind(nil, Kts); return err }(), ErrInvalidMeasurement},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.kind) {
+				t.Errorf("expected %v to be %v", tc.err, tc.kind)
+			}
+		})
+	}
+
+	if errors.Is(NewWxErr("test error", "test context"), ErrParse) {
+		t.Errorf("expected an error without a kind not to match")
+	}
+}
+
+func TestRangeError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewTempE(-300, Celsius)
+
+	var rangeErr RangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("expected RangeError, got %T", err)
+	}
+
+	if rangeErr.Measurement != -300 || rangeErr.Min != absoluteZeroC || rangeErr.Unit != "°C" {
+		t.Errorf("unexpected range error %+v", rangeErr)
+	}
+
+	if want := "-300 °C: below absolute zero (minimum -273.15 °C)"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestNewE(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		new   func() (interface{ Valid() bool }, error)
+		valid bool
+		kind  error
+	}{
+		{"temperature", func() (interface{ Valid() bool }, error) { return NewTempE(15, Celsius) }, true, nil},
+		{"absolute zero", func() (interface{ Valid() bool }, error) { return NewTempE(0, Kelvin) }, true, nil},
+		{"invalid temperature unit", func() (interface{ Valid() bool }, error) { return NewTempE(15, TempUnit{-1}) }, false, ErrInvalidUnit},
+		{"distance", func() (interface{ Valid() bool }, error) { return NewDistanceE(10, StatuteMiles) }, true, nil},
+		{"negative pressure", func() (interface{ Valid() bool }, error) { return NewPressureE(-1, HPa) }, false, ErrNegativeMagnitude},
+		{"invalid velocity unit", func() (interface{ Valid() bool }, error) { return NewVelocityE(1, VelocityUnit{}) }, false, ErrInvalidUnit},
+		{"negative velocity", func() (interface{ Valid() bool }, error) { return NewVelocityE(-5, Kts) }, false, ErrNegativeMagnitude},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := tc.new()
+			if v.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, v.Valid())
+			}
+
+			if tc.kind == nil && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if tc.kind != nil && !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}
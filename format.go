@@ -0,0 +1,393 @@
+package wx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Locale is a set of conventions for formatting measurements.
+type Locale struct {
+	// Decimal is the decimal separator.
+	Decimal string
+	// Names maps unit symbols to unit names. Units without a name
+	// are written with their symbol.
+	Names map[string]UnitName
+}
+
+// UnitName is the name of a unit in a locale.
+type UnitName struct {
+	// One is the name used for a measurement of exactly one.
+	One string
+	// Other is the name used for all other measurements.
+	Other string
+}
+
+// Locales.
+var (
+	// English formats with a decimal point and English unit names.
+	English = Locale{
+		Decimal: ".",
+		Names: map[string]UnitName{
+			"°C":    {"degree Celsius", "degrees Celsius"},
+			"°F":    {"degree Fahrenheit", "degrees Fahrenheit"},
+			"K":     {"kelvin", "kelvins"},
+			"R":     {"degree Rankine", "degrees Rankine"},
+			"ft":    {"foot", "feet"},
+			"km":    {"kilometer", "kilometers"},
+			"NM":    {"nautical mile", "nautical miles"},
+			"m":     {"meter", "meters"},
+			"SM":    {"statute mile", "statute miles"},
+			"pc":    {"parsec", "parsecs"},
+			"fur":   {"furlong", "furlongs"},
+			"hPa":   {"hectopascal", "hectopascals"},
+			"inHg":  {"inch of mercury", "inches of mercury"},
+			"kPa":   {"kilopascal", "kilopascals"},
+			"mb":    {"millibar", "millibars"},
+			"Pa":    {"pascal", "pascals"},
+			"psi":   {"pound per square inch", "pounds per square inch"},
+			"Torr":  {"torr", "torr"},
+			"fps":   {"foot per second", "feet per second"},
+			"kts":   {"knot", "knots"},
+			"kph":   {"kilometer per hour", "kilometers per hour"},
+			"mph":   {"mile per hour", "miles per hour"},
+			"mps":   {"meter per second", "meters per second"},
+			"m/min": {"meter per minute", "meters per minute"},
+			"Bft":   {"Beaufort", "Beaufort"},
+		},
+	}
+
+	// German formats with a decimal comma and German unit names.
+	German = Locale{
+		Decimal: ",",
+		Names: map[string]UnitName{
+			"°C":    {"Grad Celsius", "Grad Celsius"},
+			"°F":    {"Grad Fahrenheit", "Grad Fahrenheit"},
+			"K":     {"Kelvin", "Kelvin"},
+			"R":     {"Grad Rankine", "Grad Rankine"},
+			"ft":    {"Fuß", "Fuß"},
+			"km":    {"Kilometer", "Kilometer"},
+			"NM":    {"Seemeile", "Seemeilen"},
+			"m":     {"Meter", "Meter"},
+			"SM":    {"Meile", "Meilen"},
+			"pc":    {"Parsec", "Parsec"},
+			"fur":   {"Furlong", "Furlong"},
+			"hPa":   {"Hektopascal", "Hektopascal"},
+			"inHg":  {"Zoll Quecksilbersäule", "Zoll Quecksilbersäule"},
+			"kPa":   {"Kilopascal", "Kilopascal"},
+			"mb":    {"Millibar", "Millibar"},
+			"Pa":    {"Pascal", "Pascal"},
+			"psi":   {"Pfund pro Quadratzoll", "Pfund pro Quadratzoll"},
+			"Torr":  {"Torr", "Torr"},
+			"fps":   {"Fuß pro Sekunde", "Fuß pro Sekunde"},
+			"kts":   {"Knoten", "Knoten"},
+			"kph":   {"Kilometer pro Stunde", "Kilometer pro Stunde"},
+			"mph":   {"Meile pro Stunde", "Meilen pro Stunde"},
+			"mps":   {"Meter pro Sekunde", "Meter pro Sekunde"},
+			"m/min": {"Meter pro Minute", "Meter pro Minute"},
+			"Bft":   {"Beaufort", "Beaufort"},
+		},
+	}
+
+	// French formats with a decimal comma and French unit names.
+	French = Locale{
+		Decimal: ",",
+		Names: map[string]UnitName{
+			"°C":    {"degré Celsius", "degrés Celsius"},
+			"°F":    {"degré Fahrenheit", "degrés Fahrenheit"},
+			"K":     {"kelvin", "kelvins"},
+			"R":     {"degré Rankine", "degrés Rankine"},
+			"ft":    {"pied", "pieds"},
+			"km":    {"kilomètre", "kilomètres"},
+			"NM":    {"mille marin", "milles marins"},
+			"m":     {"mètre", "mètres"},
+			"SM":    {"mille terrestre", "milles terrestres"},
+			"pc":    {"parsec", "parsecs"},
+			"fur":   {"furlong", "furlongs"},
+			"hPa":   {"hectopascal", "hectopascals"},
+			"inHg":  {"pouce de mercure", "pouces de mercure"},
+			"kPa":   {"kilopascal", "kilopascals"},
+			"mb":    {"millibar", "millibars"},
+			"Pa":    {"pascal", "pascals"},
+			"psi":   {"livre par pouce carré", "livres par pouce carré"},
+			"Torr":  {"torr", "torrs"},
+			"fps":   {"pied par seconde", "pieds par seconde"},
+			"kts":   {"nœud", "nœuds"},
+			"kph":   {"kilomètre par heure", "kilomètres par heure"},
+			"mph":   {"mille par heure", "milles par heure"},
+			"mps":   {"mètre par seconde", "mètres par seconde"},
+			"m/min": {"mètre par minute", "mètres par minute"},
+			"Bft":   {"Beaufort", "Beaufort"},
+		},
+	}
+)
+
+// Sprintf formats like fmt.Sprintf, formatting any measurements in
+// the arguments with the conventions of the locale.
+func (l Locale) Sprintf(format string, a ...interface{}) string {
+	args := make([]interface{}, len(a))
+	for i, arg := range a {
+		if m, ok := arg.(localizable); ok {
+			arg = localized{m, &l}
+		}
+		args[i] = arg
+	}
+
+	return fmt.Sprintf(format, args...)
+}
+
+// name returns the name of the unit with the symbol for the
+// measurement formatted as num, so the name agrees with the number
+// as written after rounding.
+func (l Locale) name(symbol, num string) string {
+	n, ok := l.Names[symbol]
+	if !ok {
+		return symbol
+	}
+
+	if v, err := strconv.ParseFloat(num, 64); err == nil && math.Abs(v) == 1 {
+		return n.One
+	}
+
+	return n.Other
+}
+
+// localizable is a measurement that can be formatted with a locale.
+type localizable interface {
+	format(f fmt.State, verb rune, l *Locale)
+}
+
+// localized formats a measurement with a locale.
+type localized struct {
+	m localizable
+	l *Locale
+}
+
+// Format implements fmt.Formatter.
+func (l localized) Format(f fmt.State, verb rune) {
+	l.m.format(f, verb, l.l)
+}
+
+// measurementFormat is a measurement being formatted.
+type measurementFormat struct {
+	value  float64
+	symbol string
+	valid  bool
+	// precision is the number of decimal places used when the
+	// format has no precision.
+	precision int
+	// str is the String representation of the measurement.
+	str string
+
+	// v, unit and uncertainty are used to format the measurement in
+	// Go syntax.
+	v           interface{}
+	unit        interface{}
+	uncertainty float64
+}
+
+// newMeasurementFormat returns the format of the measurement v, which
+// holds the quantity q, with the default precision and String
+// representation of v.
+func newMeasurementFormat[D Dimension](v interface{}, q Quantity[D], precision int, str string) measurementFormat {
+	return measurementFormat{
+		value:       q.measurement,
+		symbol:      q.unit.String(),
+		valid:       q.valid,
+		precision:   precision,
+		str:         str,
+		v:           v,
+		unit:        q.unit,
+		uncertainty: q.uncertainty,
+	}
+}
+
+// format formats the measurement for the verb. A nil locale formats
+// like String when the format has no precision or flags.
+func (m measurementFormat) format(f fmt.State, verb rune, l *Locale) {
+	loc := English
+	if l != nil {
+		loc = *l
+	}
+
+	prec, hasPrec := f.Precision()
+	if !hasPrec {
+		prec = m.precision
+	}
+
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "%T{measurement:%#v, unit:%#v, valid:%#v, uncertainty:%#v}", m.v, m.value, m.unit, m.valid, m.uncertainty)
+		return
+	}
+
+	switch verb {
+	case 'v', 's', 'q':
+		s := m.str
+		if m.valid && (l != nil || hasPrec || f.Flag('+')) {
+			num := strconv.FormatFloat(m.value, 'f', prec, 64)
+
+			switch {
+			case f.Flag('+'):
+				s = loc.number(num) + " " + loc.name(m.symbol, num)
+			case strings.HasPrefix(m.symbol, "°"):
+				s = loc.number(num) + m.symbol
+			default:
+				s = loc.number(num) + " " + m.symbol
+			}
+		}
+
+		if verb == 'q' {
+			s = strconv.Quote(s)
+		}
+
+		pad(f, s)
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		if !m.valid {
+			pad(f, "invalid")
+			return
+		}
+
+		fmt.Fprint(f, loc.number(fmt.Sprintf(directive(f, verb), m.value)))
+	case 'd':
+		if !m.valid {
+			pad(f, "invalid")
+			return
+		}
+
+		fmt.Fprintf(f, directive(f, verb), int64(math.Round(m.value)))
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, m.str)
+	}
+}
+
+// number replaces the decimal point of a formatted number with the
+// decimal separator of the locale.
+func (l Locale) number(s string) string {
+	if l.Decimal == "" || l.Decimal == "." {
+		return s
+	}
+
+	return strings.Replace(s, ".", l.Decimal, 1)
+}
+
+// directive rebuilds the formatting directive for the verb from the
+// state, so a number can be formatted with the same flags, width and
+// precision.
+func directive(f fmt.State, verb rune) string {
+	var b strings.Builder
+	b.WriteByte('%')
+
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			b.WriteRune(flag)
+		}
+	}
+
+	if w, ok := f.Width(); ok {
+		b.WriteString(strconv.Itoa(w))
+	}
+
+	if p, ok := f.Precision(); ok {
+		b.WriteByte('.')
+		b.WriteString(strconv.Itoa(p))
+	}
+
+	b.WriteRune(verb)
+
+	return b.String()
+}
+
+// pad writes the string padded to the width of the state.
+func pad(f fmt.State, s string) {
+	w, ok := f.Width()
+	if !ok || utf8.RuneCountInString(s) >= w {
+		fmt.Fprint(f, s)
+		return
+	}
+
+	padding := strings.Repeat(" ", w-utf8.RuneCountInString(s))
+	if f.Flag('-') {
+		fmt.Fprint(f, s+padding)
+		return
+	}
+
+	fmt.Fprint(f, padding+s)
+}
+
+// Format implements fmt.Formatter with the following verbs:
+//
+//	%v, %s  the measurement and unit symbol, e.g. "12.0 kts"
+//	%+v     the measurement and unit name, e.g. "12.0 knots"
+//	%#v     the measurement in Go syntax
+//	%q      a double-quoted %v
+//	%f, %e, %g and upper case variants
+//	        the measurement alone, formatted as a float64
+//	%d      the measurement rounded to an integer
+//
+// The precision sets the number of decimal places, so "%.0v" formats
+// 12.34 knots as "12 kts". The unit name is singular if the number is
+// written as one, so "%+.0v" formats 1.2 knots as "1 knot". Without a precision %v and %s format the
+// same as the String method. The width pads the whole result, and the
+// '-' flag pads on the right. Invalid measurements format as their
+// String method for %v and %s and as "invalid" for the number verbs.
+//
+// Formatting with a Locale uses its decimal separator and unit names.
+func (q Quantity[D]) Format(f fmt.State, verb rune) {
+	q.format(f, verb, nil)
+}
+
+func (q Quantity[D]) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(q, q, 2, q.String()).format(f, verb, l)
+}
+
+// Format implements fmt.Formatter as described by Quantity.Format.
+// Temperatures default to one decimal place.
+func (t Temp) Format(f fmt.State, verb rune) {
+	t.format(f, verb, nil)
+}
+
+func (t Temp) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(t, t.Quantity(), 1, t.String()).format(f, verb, l)
+}
+
+// Format implements fmt.Formatter as described by Quantity.Format.
+// Distances default to two decimal places.
+func (d Distance) Format(f fmt.State, verb rune) {
+	d.format(f, verb, nil)
+}
+
+func (d Distance) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(d, d.Quantity(), 2, d.String()).format(f, verb, l)
+}
+
+// Format implements fmt.Formatter as described by Quantity.Format.
+// Pressures default to two decimal places.
+func (p Pressure) Format(f fmt.State, verb rune) {
+	p.format(f, verb, nil)
+}
+
+func (p Pressure) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(p, p.Quantity(), 2, p.String()).format(f, verb, l)
+}
+
+// Format implements fmt.Formatter as described by Quantity.Format.
+// Velocities default to one decimal place.
+func (v Velocity) Format(f fmt.State, verb rune) {
+	v.format(f, verb, nil)
+}
+
+func (v Velocity) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(v, v.Quantity(), 1, v.String()).format(f, verb, l)
+}
+
+// Format implements fmt.Formatter as described by Quantity.Format.
+// Altitudes default to two decimal places.
+func (a Altitude) Format(f fmt.State, verb rune) {
+	a.format(f, verb, nil)
+}
+
+func (a Altitude) format(f fmt.State, verb rune, l *Locale) {
+	newMeasurementFormat(a, a.quantity(), 2, a.String()).format(f, verb, l)
+}
@@ -0,0 +1,87 @@
+package wx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		format string
+		v      interface{}
+		want   string
+	}{
+		{"default distance", "%v", NewDistance(10, StatuteMiles), "10.00 SM"},
+		{"altitude below sea level", "%+.0v", NewAltitude(-1407, Feet), "-1407 feet"},
+		{"default velocity", "%s", NewVelocity(12, Kts), "12.0 kts"},
+		{"default temperature", "%v", NewTemp(-5, Celsius), "-5.0°C"},
+		{"default kelvin", "%v", NewTemp(273.15, Kelvin), "273.1 K"},
+		{"precision", "%.3v", NewPressure(29.921, InHg), "29.921 inHg"},
+		{"integer precision", "%.0v", NewDistance(5434.4, Feet), "5434 ft"},
+		{"unit name", "%+v", NewVelocity(12, Kts), "12.0 knots"},
+		{"singular unit name", "%+.0v", NewDistance(1, NauticalMiles), "1 nautical mile"},
+		{"rounded to one", "%+.0v", NewVelocity(1.2, Kts), "1 knot"},
+		{"rounded up to one", "%+.2v", NewDistance(0.9999, NauticalMiles), "1.00 nautical mile"},
+		{"rounded away from one", "%+.1v", NewVelocity(1.06, Kts), "1.1 knots"},
+		{"temperature name", "%+v", NewTemp(-5, Celsius), "-5.0 degrees Celsius"},
+		{"go syntax", "%#v", NewVelocity(12, Kts), "wx.Velocity{measurement:12, unit:wx.VelocityUnit{velocityType:0x2}, valid:true, uncertainty:0}"},
+		{"quoted", "%q", NewVelocity(5, Mps), `"5.0 mps"`},
+		{"width", "%10v", NewVelocity(5, Mps), "   5.0 mps"},
+		{"left justified", "%-10v|", NewVelocity(5, Mps), "5.0 mps   |"},
+		{"number only", "%.2f", NewPressure(1013.25, HPa), "1013.25"},
+		{"number width", "%08.2f", NewPressure(29.92, InHg), "00029.92"},
+		{"scientific", "%.3e", NewDistance(1, Parsec), "1.000e+00"},
+		{"integer", "%d", NewDistance(5434.6, Feet), "5435"},
+		{"integer width", "%05d", NewVelocity(7, Kts), "00007"},
+		{"invalid distance", "%.0v", NewDistance(-1, Feet), "invalid ft"},
+		{"invalid velocity", "%v", Velocity{}, "invalid velocity"},
+		{"invalid number", "%f", NewDistance(-1, Feet), "invalid"},
+		{"bad verb", "%x", NewVelocity(5, Mps), "%!x(5.0 mps)"},
+		{"quantity", "%.1v", NewQuantity(60, Mpm), "60.0 m/min"},
+		{"quantity name", "%+v", NewQuantity(4, Beaufort), "4.00 Beaufort"},
+		{"wind", "%v", NewWind(NewWindDirection(270), NewVelocity(15, Kts)), "270° at 15.0 kts"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tc.format, tc.v); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLocale_Sprintf(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		locale Locale
+		format string
+		a      []interface{}
+		want   string
+	}{
+		{"german default", German, "%v", []interface{}{NewVelocity(12, Kts)}, "12,0 kts"},
+		{"german name", German, "%+v", []interface{}{NewVelocity(12, Kts)}, "12,0 Knoten"},
+		{"french name", French, "%+.0v", []interface{}{NewVelocity(12, Kts)}, "12 nœuds"},
+		{"french rounded singular", French, "%+v", []interface{}{NewVelocity(0.99, Kts)}, "1,0 nœud"},
+		{"french singular", French, "%+.0v", []interface{}{NewVelocity(1, Kts)}, "1 nœud"},
+		{"german temperature", German, "%.1v", []interface{}{NewTemp(-5.25, Celsius)}, "-5,2°C"},
+		{"german number", German, "%.2f hPa", []interface{}{NewPressure(1013.25, HPa)}, "1013,25 hPa"},
+		{"english", English, "%+.1v", []interface{}{NewDistance(2, StatuteMiles)}, "2.0 statute miles"},
+		{"other arguments", German, "%s: %v, %.1f", []interface{}{"QNH", NewPressure(1013, HPa), 1.5}, "QNH: 1013,00 hPa, 1.5"},
+		{"invalid", French, "%v", []interface{}{NewDistance(-1, Meters)}, "invalid m"},
+		{"missing name", Locale{Decimal: ","}, "%+v", []interface{}{NewVelocity(3, Beaufort)}, "3,0 Bft"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.locale.Sprintf(tc.format, tc.a...); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package wx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Humidity represents a relative humidity measurement
+// as a percentage between 0 and 100.
+type Humidity struct {
+	percent     float64
+	valid       bool
+	uncertainty float64
+}
+
+// NewHumidity creates a new relative humidity measurement from
+// a percentage. The humidity is valid between 0 and 100 percent.
+func NewHumidity(percent float64) Humidity {
+	return Humidity{
+		percent: percent,
+		valid:   percent >= 0 && percent <= 100,
+	}
+}
+
+// Percent returns the relative humidity as a percentage.
+func (h Humidity) Percent() float64 {
+	return h.percent
+}
+
+// Fraction returns the relative humidity as a fraction
+// between 0 and 1.
+func (h Humidity) Fraction() float64 {
+	return h.percent / 100
+}
+
+// Valid returns true if the humidity is valid.
+func (h Humidity) Valid() bool {
+	return h.valid
+}
+
+// String returns the string representation of the humidity.
+func (h Humidity) String() string {
+	if !h.valid {
+		return "invalid %"
+	}
+
+	return fmt.Sprintf("%.1f%%", h.percent)
+}
+
+// formulationType represents a saturation vapor pressure formulation.
+type formulationType uint8
+
+// Saturation vapor pressure formulations.
+// The values start at 1 so the zero value is not a valid
+// formulation.
+const (
+	magnus       formulationType = iota + 1 // Magnus (Alduchov and Eskridge, 1996)
+	buck                                    // Buck (1996)
+	hylandWexler                            // Hyland and Wexler (1983)
+)
+
+// String returns the string representation of the formulation.
+func (f formulationType) String() string {
+	switch f {
+	case magnus:
+		return "Magnus"
+	case buck:
+		return "Buck"
+	case hylandWexler:
+		return "Hyland-Wexler"
+	}
+
+	return ""
+}
+
+// Formulation is a saturation vapor pressure formulation used
+// for moisture calculations.
+type Formulation struct {
+	formulationType
+}
+
+// String returns the string representation of the formulation.
+func (f Formulation) String() string {
+	return f.formulationType.String()
+}
+
+// Saturation vapor pressure formulations.
+var (
+	// Magnus is the Magnus formula with the coefficients of
+	// Alduchov and Eskridge (1996). It is accurate to within 0.4%
+	// between -40 and 50 °C and can be inverted exactly.
+	Magnus = Formulation{magnus}
+	// Buck is the formula of Buck (1981) with the 1996 coefficients.
+	Buck = Formulation{buck}
+	// HylandWexler is the formulation of Hyland and Wexler (1983)
+	// used by the WMO and ASHRAE as the reference formulation.
+	HylandWexler = Formulation{hylandWexler}
+)
+
+const (
+	// Lower bound in Celsius when solving for a dew or frost point.
+	minDewPointC = -150.0
+	// Upper bound in Celsius when solving for a dew or frost point.
+	maxDewPointC = 150.0
+)
+
+// DewPoint returns the dew point for a temperature and relative
+// humidity using the given formulation. The result is in the same
+// unit as t and is invalid if either input is invalid.
+func DewPoint(t Temp, rh Humidity, f Formulation) Temp {
+	return saturationPoint(t, rh, f, false)
+}
+
+// FrostPoint returns the frost point for a temperature and relative
+// humidity using the given formulation. The frost point is the
+// temperature at which the air is saturated with respect to ice and
+// is only meaningful below freezing. Relative humidity is taken with
+// respect to water, as reported by the WMO convention.
+func FrostPoint(t Temp, rh Humidity, f Formulation) Temp {
+	return saturationPoint(t, rh, f, true)
+}
+
+// RelativeHumidity returns the relative humidity with respect to
+// water for a temperature and dew point using the given formulation.
+// The result is invalid if either input is invalid or the dew
+// point exceeds the temperature.
+func RelativeHumidity(t, dewPoint Temp, f Formulation) Humidity {
+	if !t.valid || !dewPoint.valid || f.formulationType == 0 {
+		return Humidity{}
+	}
+
+	rh := func(x []float64) float64 {
+		return saturationVaporPressure(x[1], f, false) / saturationVaporPressure(x[0], f, false) * 100
+	}
+
+	tc, dc := t.ToC(), dewPoint.ToC()
+	x := []float64{tc.measurement, dc.measurement}
+	sigma := []float64{tc.uncertainty, dc.uncertainty}
+
+	return NewHumidity(rh(x)).WithUncertainty(propagate(rh, x, sigma))
+}
+
+// saturationPoint solves for the temperature at which the vapor
+// pressure of the air equals the saturation vapor pressure over
+// water or ice.
+func saturationPoint(t Temp, rh Humidity, f Formulation, ice bool) Temp {
+	if !t.valid || !rh.valid || rh.percent == 0 || f.formulationType == 0 {
+		return Temp{}
+	}
+
+	point := func(x []float64) float64 {
+		e := x[1] / 100 * saturationVaporPressure(x[0], f, false)
+		c, _ := inverseSaturationVaporPressure(e, f, ice)
+		return c
+	}
+
+	tc := t.ToC()
+	e := rh.Fraction() * saturationVaporPressure(tc.measurement, f, false)
+
+	c, ok := inverseSaturationVaporPressure(e, f, ice)
+	if !ok {
+		return Temp{}
+	}
+
+	x := []float64{tc.measurement, rh.percent}
+	sigma := []float64{tc.uncertainty, rh.uncertainty}
+
+	return NewTemp(c, Celsius).WithUncertainty(propagate(point, x, sigma)).To(t.unit)
+}
+
+// saturationVaporPressure returns the saturation vapor pressure in
+// hectopascals over water or ice for a temperature in Celsius.
+func saturationVaporPressure(c float64, f Formulation, ice bool) float64 {
+	switch f.formulationType {
+	case magnus:
+		if ice {
+			return 6.1121 * math.Exp(22.587*c/(c+273.86))
+		}
+		return 6.1094 * math.Exp(17.625*c/(c+243.04))
+	case buck:
+		if ice {
+			return 6.1115 * math.Exp((23.036-c/333.7)*(c/(279.82+c)))
+		}
+		return 6.1121 * math.Exp((18.678-c/234.5)*(c/(257.14+c)))
+	case hylandWexler:
+		k := c - absoluteZeroC
+		if ice {
+			return math.Exp(-5.6745359e3/k+6.3925247-9.677843e-3*k+
+				6.2215701e-7*k*k+2.0747825e-9*k*k*k-
+				9.484024e-13*k*k*k*k+4.1635019*math.Log(k)) / 100
+		}
+		return math.Exp(-5.8002206e3/k+1.3914993-4.8640239e-2*k+
+			4.1764768e-5*k*k-1.4452093e-8*k*k*k+
+			6.5459673*math.Log(k)) / 100
+	}
+
+	return 0
+}
+
+// inverseSaturationVaporPressure returns the temperature in Celsius
+// at which the saturation vapor pressure in hectopascals equals e.
+// The Magnus formula is inverted exactly; the other formulations
+// are solved by bisection.
+func inverseSaturationVaporPressure(e float64, f Formulation, ice bool) (float64, bool) {
+	if e <= 0 {
+		return 0, false
+	}
+
+	if f.formulationType == magnus {
+		a, b, c := 17.625, 243.04, 6.1094
+		if ice {
+			a, b, c = 22.587, 273.86, 6.1121
+		}
+
+		l := math.Log(e / c)
+
+		return b * l / (a - l), true
+	}
+
+	lo, hi := minDewPointC, maxDewPointC
+	if e < saturationVaporPressure(lo, f, ice) || e > saturationVaporPressure(hi, f, ice) {
+		return 0, false
+	}
+
+	for i := 0; i < 100 && hi-lo > 1e-10; i++ {
+		mid := (lo + hi) / 2
+		if saturationVaporPressure(mid, f, ice) < e {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2, true
+}
@@ -0,0 +1,221 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestNewHumidity(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name    string
+		percent float64
+		valid   bool
+		str     string
+	}{
+		{"zero", 0, true, "0.0%"},
+		{"fifty", 50, true, "50.0%"},
+		{"saturated", 100, true, "100.0%"},
+		{"negative", -1, false, "invalid %"},
+		{"supersaturated", 100.1, false, "invalid %"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			h := NewHumidity(tc.percent)
+			if h.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, h.Valid())
+			}
+
+			if h.Percent() != tc.percent || h.Fraction() != tc.percent/100 {
+				t.Errorf("expected %v%%, got %v%%", tc.percent, h.Percent())
+			}
+
+			if h.String() != tc.str {
+				t.Errorf("expected %q, got %q", tc.str, h.String())
+			}
+		})
+	}
+}
+
+func TestFormulation_String(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		f    Formulation
+		want string
+	}{
+		{"magnus", Magnus, "Magnus"},
+		{"buck", Buck, "Buck"},
+		{"hyland-wexler", HylandWexler, "Hyland-Wexler"},
+		{"invalid", Formulation{}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.f.String() != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, tc.f.String())
+			}
+		})
+	}
+}
+
+func TestSaturationVaporPressure(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		c    float64
+		f    Formulation
+		ice  bool
+		want float64
+	}{
+		{"magnus water", 0, Magnus, false, 6.1094},
+		{"magnus ice", 0, Magnus, true, 6.1121},
+		{"buck water", 0, Buck, false, 6.1121},
+		{"buck ice", 0, Buck, true, 6.1115},
+		{"hyland-wexler water", 0, HylandWexler, false, 6.112129},
+		{"hyland-wexler ice", 0, HylandWexler, true, 6.111536},
+		{"hyland-wexler boiling", 100, HylandWexler, false, 1014.19},
+		{"invalid", 0, Formulation{}, false, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := saturationVaporPressure(tc.c, tc.f, tc.ice)
+			if !tests.CloseEnough(got, tc.want, 0.5) || (tc.c == 0 && !tests.CloseEnough(got, tc.want, 1e-5)) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDewPoint(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		rh    Humidity
+		f     Formulation
+		want  float64
+		valid bool
+	}{
+		{"magnus", NewTemp(20, Celsius), NewHumidity(50), Magnus, 9.2611, true},
+		{"magnus humid", NewTemp(30, Celsius), NewHumidity(70), Magnus, 23.9305, true},
+		{"buck", NewTemp(20, Celsius), NewHumidity(50), Buck, 9.2710, true},
+		{"hyland-wexler", NewTemp(20, Celsius), NewHumidity(50), HylandWexler, 9.2724, true},
+		{"saturated", NewTemp(15, Celsius), NewHumidity(100), HylandWexler, 15, true},
+		{"below freezing", NewTemp(-10, Celsius), NewHumidity(80), Magnus, -12.7951, true},
+		{"dry", NewTemp(20, Celsius), NewHumidity(0), Magnus, 0, false},
+		{"invalid temp", NewTemp(-500, Celsius), NewHumidity(50), Magnus, 0, false},
+		{"invalid humidity", NewTemp(20, Celsius), NewHumidity(120), Magnus, 0, false},
+		{"invalid formulation", NewTemp(20, Celsius), NewHumidity(50), Formulation{}, 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			td := DewPoint(tc.temp, tc.rh, tc.f)
+			if td.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, td.Valid())
+			}
+
+			if tc.valid && !tests.CloseEnough(td.C(), tc.want, 0.01) {
+				t.Errorf("expected %v, got %v", tc.want, td.C())
+			}
+		})
+	}
+}
+
+func TestDewPoint_Unit(t *testing.T) {
+	t.Parallel()
+
+	td := DewPoint(NewTemp(68, Fahrenheit), NewHumidity(50), Magnus)
+	if td.Unit() != Fahrenheit {
+		t.Errorf("expected %v, got %v", Fahrenheit, td.Unit())
+	}
+
+	if !tests.CloseEnough(td.C(), 9.2611, 1e-3) {
+		t.Errorf("expected 9.2611, got %v", td.C())
+	}
+}
+
+func TestFrostPoint(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		f    Formulation
+		want float64
+	}{
+		{"magnus", Magnus, -11.3869},
+		{"buck", Buck, -11.39},
+		{"hyland-wexler", HylandWexler, -11.39},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tf := FrostPoint(NewTemp(-10, Celsius), NewHumidity(80), tc.f)
+			if !tests.CloseEnough(tf.C(), tc.want, 0.02) {
+				t.Errorf("expected %v, got %v", tc.want, tf.C())
+			}
+
+			// The frost point is always above the dew point.
+			if td := DewPoint(NewTemp(-10, Celsius), NewHumidity(80), tc.f); tf.C() <= td.C() {
+				t.Errorf("expected frost point %v above dew point %v", tf.C(), td.C())
+			}
+		})
+	}
+}
+
+func TestRelativeHumidity(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		dew   Temp
+		f     Formulation
+		want  float64
+		valid bool
+	}{
+		{"saturated", NewTemp(15, Celsius), NewTemp(15, Celsius), Magnus, 100, true},
+		{"magnus", NewTemp(20, Celsius), NewTemp(9.2611, Celsius), Magnus, 50, true},
+		{"hyland-wexler", NewTemp(30, Celsius), NewTemp(20, Celsius), HylandWexler, 55.1, true},
+		{"mixed units", NewTemp(68, Fahrenheit), NewTemp(282.4111, Kelvin), Magnus, 50, true},
+		{"dew point above temp", NewTemp(10, Celsius), NewTemp(12, Celsius), Magnus, 0, false},
+		{"invalid dew point", NewTemp(10, Celsius), Temp{}, Magnus, 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rh := RelativeHumidity(tc.temp, tc.dew, tc.f)
+			if rh.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, rh.Valid())
+			}
+
+			if tc.valid && !tests.CloseEnough(rh.Percent(), tc.want, 0.1) {
+				t.Errorf("expected %v, got %v", tc.want, rh.Percent())
+			}
+		})
+	}
+}
+
+func TestDewPoint_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, f := range []Formulation{Magnus, Buck, HylandWexler} {
+		for c := -40.0; c <= 50; c += 10 {
+			for rh := 5.0; rh <= 100; rh += 15 {
+				temp := NewTemp(c, Celsius)
+				got := RelativeHumidity(temp, DewPoint(temp, NewHumidity(rh), f), f)
+				if !tests.CloseEnough(got.Percent(), rh, 1e-6) {
+					t.Errorf("%v: %v °C %v%%: round trip gave %v%%", f, c, rh, got.Percent())
+				}
+			}
+		}
+	}
+}
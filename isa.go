@@ -0,0 +1,186 @@
+package wx
+
+import "math"
+
+const (
+	// isaGasConstant is the specific gas constant for air used by
+	// the standard atmosphere (R*/M0) in J/(kg·K).
+	isaGasConstant = 8.31432 / 0.0289644
+
+	// isaHeatCapacityRatio is the ratio of specific heats for air.
+	isaHeatCapacityRatio = 1.4
+
+	// isaEarthRadius is the effective earth radius in meters used to
+	// convert between geometric and geopotential altitude.
+	isaEarthRadius = 6_356_766.0
+
+	// isaMinAltitude is the lowest geopotential altitude in meters
+	// covered by the standard atmosphere.
+	isaMinAltitude = -5_000.0
+
+	// isaMaxAltitude is the highest geopotential altitude in meters
+	// covered by the standard atmosphere (86 km geometric).
+	isaMaxAltitude = isaEarthRadius * 86_000 / (isaEarthRadius + 86_000)
+)
+
+// isaLayer is a layer of the standard atmosphere with a constant
+// temperature lapse rate.
+type isaLayer struct {
+	base     float64 // geopotential altitude of the base in meters
+	temp     float64 // temperature at the base in kelvin
+	lapse    float64 // lapse rate in kelvin per geopotential meter
+	pressure float64 // pressure at the base in pascals
+}
+
+// isaLayers are the layers of the ICAO standard atmosphere (identical
+// to the U.S. Standard Atmosphere, 1976) up to 86 km. The base
+// pressures are derived from the sea level pressure when the
+// package is initialized.
+var isaLayers = func() []isaLayer {
+	layers := []isaLayer{
+		{base: 0, temp: standardTempK, lapse: -standardLapseRate},
+		{base: 11_000, lapse: 0},
+		{base: 20_000, lapse: 0.001},
+		{base: 32_000, lapse: 0.0028},
+		{base: 47_000, lapse: 0},
+		{base: 51_000, lapse: -0.0028},
+		{base: 71_000, lapse: -0.002},
+	}
+
+	layers[0].pressure = standardPressureHPa * 100
+	for i := 1; i < len(layers); i++ {
+		prev := layers[i-1]
+		layers[i].temp = prev.temp + prev.lapse*(layers[i].base-prev.base)
+		layers[i].pressure = prev.pressureAt(layers[i].base)
+	}
+
+	return layers
+}()
+
+// tempAt returns the temperature in kelvin at a geopotential altitude
+// within the layer.
+func (l isaLayer) tempAt(h float64) float64 {
+	return l.temp + l.lapse*(h-l.base)
+}
+
+// pressureAt returns the pressure in pascals at a geopotential
+// altitude within the layer.
+func (l isaLayer) pressureAt(h float64) float64 {
+	if l.lapse == 0 {
+		return l.pressure * math.Exp(-gravity*(h-l.base)/(isaGasConstant*l.temp))
+	}
+
+	return l.pressure * math.Pow(l.temp/l.tempAt(h), gravity/(isaGasConstant*l.lapse))
+}
+
+// altitudeAt returns the geopotential altitude in meters at which
+// the pressure in pascals is reached within the layer.
+func (l isaLayer) altitudeAt(p float64) float64 {
+	if l.lapse == 0 {
+		return l.base - isaGasConstant*l.temp/gravity*math.Log(p/l.pressure)
+	}
+
+	return l.base + l.temp/l.lapse*(math.Pow(p/l.pressure, -isaGasConstant*l.lapse/gravity)-1)
+}
+
+// Atmosphere is the state of the standard atmosphere at an altitude.
+type Atmosphere struct {
+	// Temp is the air temperature in kelvin.
+	Temp Temp
+	// Pressure is the air pressure in hectopascals.
+	Pressure Pressure
+	// Density is the air density in kg/m³.
+	Density float64
+	// SpeedOfSound is the speed of sound in meters per second.
+	SpeedOfSound Velocity
+}
+
+// StandardAtmosphere returns the ICAO standard atmosphere at a
+// geopotential altitude between -5 km and about 84.852 km (86 km
+// geometric). An error is returned if the altitude is invalid or
+// outside of the model.
+func StandardAtmosphere(altitude Altitude) (Atmosphere, error) {
+	h := altitude.M()
+	if !altitude.valid || h < isaMinAltitude || h > isaMaxAltitude {
+		return Atmosphere{}, NewWxErrKind(ErrOutOfDomain, "altitude out of range", "standard atmosphere")
+	}
+
+	l := isaLayerAt(h)
+	t := l.tempAt(h)
+	p := l.pressureAt(h)
+
+	return Atmosphere{
+		Temp:         NewTemp(t, Kelvin),
+		Pressure:     NewPressure(p/100, HPa),
+		Density:      p / (isaGasConstant * t),
+		SpeedOfSound: NewVelocity(math.Sqrt(isaHeatCapacityRatio*isaGasConstant*t), Mps),
+	}, nil
+}
+
+// StandardAtmosphereGeometric returns the ICAO standard atmosphere
+// at a geometric altitude between -5 km and 86 km. An error is
+// returned if the altitude is invalid or outside of the model.
+func StandardAtmosphereGeometric(altitude Altitude) (Atmosphere, error) {
+	return StandardAtmosphere(GeopotentialAltitude(altitude))
+}
+
+// StandardPressureAltitude returns the geopotential altitude in
+// meters at which the standard atmosphere has the given pressure.
+// Pressures above the standard sea level pressure give a negative
+// altitude. An error is returned if the pressure is outside of the
+// model.
+func StandardPressureAltitude(p Pressure) (Altitude, error) {
+	pa := p.Pa()
+	top := isaLayers[len(isaLayers)-1].pressureAt(isaMaxAltitude)
+	bottom := isaLayers[0].pressureAt(isaMinAltitude)
+	if !p.valid || pa < top || pa > bottom {
+		return Altitude{}, NewWxErrKind(ErrOutOfDomain, "pressure out of range", "standard atmosphere")
+	}
+
+	l := isaLayers[0]
+	for _, next := range isaLayers[1:] {
+		if pa > next.pressure {
+			break
+		}
+		l = next
+	}
+
+	return NewAltitude(l.altitudeAt(pa), Meters), nil
+}
+
+// GeopotentialAltitude converts a geometric altitude to a
+// geopotential altitude in meters.
+func GeopotentialAltitude(geometric Altitude) Altitude {
+	if !geometric.valid {
+		return Altitude{valid: false}
+	}
+
+	z := geometric.M()
+
+	return NewAltitude(isaEarthRadius*z/(isaEarthRadius+z), Meters)
+}
+
+// GeometricAltitude converts a geopotential altitude to a
+// geometric altitude in meters.
+func GeometricAltitude(geopotential Altitude) Altitude {
+	if !geopotential.valid {
+		return Altitude{valid: false}
+	}
+
+	h := geopotential.M()
+
+	return NewAltitude(isaEarthRadius*h/(isaEarthRadius-h), Meters)
+}
+
+// isaLayerAt returns the layer containing a geopotential altitude.
+func isaLayerAt(h float64) isaLayer {
+	l := isaLayers[0]
+	for _, next := range isaLayers[1:] {
+		if h < next.base {
+			break
+		}
+		l = next
+	}
+
+	return l
+}
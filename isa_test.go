@@ -0,0 +1,171 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestStandardAtmosphere(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		altitude Altitude
+		temp     float64
+		pressure float64
+		density  float64
+		sound    float64
+	}{
+		{"sea level", NewAltitude(0, Meters), 288.15, 101325, 1.225, 340.294},
+		{"1000 m", NewAltitude(1000, Meters), 281.65, 89874.6, 1.1117, 336.434},
+		{"tropopause", NewAltitude(11, Kilometers), 216.65, 22632.1, 0.36392, 295.070},
+		{"stratosphere", NewAltitude(20000, Meters), 216.65, 5474.89, 0.088035, 295.070},
+		{"32 km", NewAltitude(32000, Meters), 228.65, 868.019, 0.013225, 303.131},
+		{"stratopause", NewAltitude(47000, Meters), 270.65, 110.906, 0.0014275, 329.799},
+		{"mesosphere", NewAltitude(51000, Meters), 270.65, 66.9389, 0.00086160, 329.799},
+		{"71 km", NewAltitude(71000, Meters), 214.65, 3.95642, 0.000064211, 293.704},
+		{"top", NewAltitude(84852, Meters), 186.946, 0.37338, 0.0000069579, 274.096},
+		{"below sea level", NewAltitude(-1000, Meters), 294.65, 113929, 1.3470, 344.111},
+		{"feet", NewAltitude(10000, Feet), 268.338, 69681.7, 0.90464, 328.387},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := StandardAtmosphere(tc.altitude)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(a.Temp.K(), tc.temp, 1e-3) {
+				t.Errorf("expected temperature %v, got %v", tc.temp, a.Temp.K())
+			}
+
+			if !tests.CloseEnough(a.Pressure.Pa(), tc.pressure, tc.pressure*1e-5) {
+				t.Errorf("expected pressure %v, got %v", tc.pressure, a.Pressure.Pa())
+			}
+
+			if !tests.CloseEnough(a.Density, tc.density, tc.density*1e-4) {
+				t.Errorf("expected density %v, got %v", tc.density, a.Density)
+			}
+
+			if !tests.CloseEnough(a.SpeedOfSound.Mps(), tc.sound, 1e-3) {
+				t.Errorf("expected speed of sound %v, got %v", tc.sound, a.SpeedOfSound.Mps())
+			}
+		})
+	}
+}
+
+func TestStandardAtmosphere_OutOfRange(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		altitude Altitude
+	}{
+		{"too low", NewAltitude(-5001, Meters)},
+		{"too high", NewAltitude(85, Kilometers)},
+		{"invalid unit", NewAltitude(0, DistanceUnit{99})},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := StandardAtmosphere(tc.altitude); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestStandardAtmosphereGeometric(t *testing.T) {
+	t.Parallel()
+
+	a, err := StandardAtmosphereGeometric(NewAltitude(86, Kilometers))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !tests.CloseEnough(a.Pressure.Pa(), 0.3734, 1e-3) {
+		t.Errorf("expected 0.3734 Pa at 86 km, got %v", a.Pressure.Pa())
+	}
+
+	if _, err := StandardAtmosphereGeometric(NewAltitude(87, Kilometers)); err == nil {
+		t.Errorf("expected an error above 86 km")
+	}
+}
+
+func TestStandardPressureAltitude(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		pressure Pressure
+		want     float64
+		isErr    bool
+	}{
+		{"sea level", NewPressure(1013.25, HPa), 0, false},
+		{"1000 m", NewPressure(898.746, HPa), 1000, false},
+		{"tropopause", NewPressure(226.321, HPa), 11000, false},
+		{"stratosphere", NewPressure(10, HPa), 31054.64, false},
+		{"mesosphere", NewPressure(1, Pa), 79302.63, false},
+		{"below sea level", NewPressure(30.92, InHg), -277.81, false},
+		{"too high", NewPressure(0.1, Pa), 0, true},
+		{"too low", NewPressure(2000, HPa), 0, true},
+		{"invalid", NewPressure(-1, HPa), 0, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := StandardPressureAltitude(tc.pressure)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if !tc.isErr && !tests.CloseEnough(h.M(), tc.want, 0.5) {
+				t.Errorf("expected %v m, got %v", tc.want, h.M())
+			}
+
+			if h.Valid() == tc.isErr {
+				t.Errorf("expected valid %v, got %v", !tc.isErr, h.Valid())
+			}
+		})
+	}
+}
+
+func TestStandardPressureAltitude_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for m := -5000.0; m <= 84000; m += 500 {
+		a, err := StandardAtmosphere(NewAltitude(m, Meters))
+		if err != nil {
+			t.Fatalf("unexpected error at %v m: %v", m, err)
+		}
+
+		h, err := StandardPressureAltitude(a.Pressure)
+		if err != nil {
+			t.Fatalf("unexpected error at %v m: %v", m, err)
+		}
+
+		if !tests.CloseEnough(h.M(), m, 1e-6) {
+			t.Errorf("expected %v m, got %v", m, h.M())
+		}
+	}
+}
+
+func TestGeopotentialAltitude(t *testing.T) {
+	t.Parallel()
+
+	h := GeopotentialAltitude(NewAltitude(86, Kilometers))
+	if !tests.CloseEnough(h.M(), 84852.05, 0.01) {
+		t.Errorf("expected 84852.05 m, got %v", h.M())
+	}
+
+	z := GeometricAltitude(h)
+	if !tests.CloseEnough(z.M(), 86000, 1e-6) {
+		t.Errorf("expected 86000 m, got %v", z.M())
+	}
+
+	if GeopotentialAltitude(Altitude{}).Valid() || GeometricAltitude(Altitude{}).Valid() {
+		t.Errorf("expected an invalid distance for an invalid unit")
+	}
+}
@@ -0,0 +1,89 @@
+// Package magnetic computes the magnetic declination from the World
+// Magnetic Model and converts directions between true and magnetic
+// north.
+//
+// METAR and TAF winds are reported relative to true north, while
+// ATIS winds and runway headings are relative to magnetic north.
+package magnetic
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-wx/wx"
+)
+
+// Declination is the angle from true north to magnetic north. It is
+// positive when magnetic north is east of true north and negative
+// when it is west.
+type Declination struct {
+	degrees float64
+}
+
+// NewDeclination creates a new Declination from degrees east of true
+// north, e.g. a published magnetic variation. The value is
+// normalized to be between -180 and 180 degrees.
+func NewDeclination(deg float64) Declination {
+	deg = math.Mod(deg, 360)
+	switch {
+	case deg > 180:
+		deg -= 360
+	case deg <= -180:
+		deg += 360
+	}
+
+	return Declination{degrees: deg}
+}
+
+// DeclinationAt returns the magnetic declination from the embedded
+// World Magnetic Model at a geodetic latitude and longitude in
+// degrees, an altitude above the WGS 84 ellipsoid and a date.
+// See Model.Field for the errors returned.
+func DeclinationAt(lat, lon float64, altitude wx.Altitude, date time.Time) (Declination, error) {
+	return WMM.Declination(lat, lon, altitude, date)
+}
+
+// Degrees returns the declination in degrees, positive east.
+func (d Declination) Degrees() float64 {
+	return d.degrees
+}
+
+// East returns true if magnetic north is east of true north.
+func (d Declination) East() bool {
+	return d.degrees > 0
+}
+
+// String returns the string representation of the declination,
+// e.g. 7.5°E or 12.6°W.
+func (d Declination) String() string {
+	if d.degrees < 0 {
+		return fmt.Sprintf("%.1f°W", -d.degrees)
+	}
+
+	return fmt.Sprintf("%.1f°E", d.degrees)
+}
+
+// ToMagnetic converts a direction relative to true north to one
+// relative to magnetic north.
+func (d Declination) ToMagnetic(dir wx.Degrees) wx.Degrees {
+	return wx.NewDegrees(dir.Degrees() - d.degrees)
+}
+
+// ToTrue converts a direction relative to magnetic north to one
+// relative to true north.
+func (d Declination) ToTrue(dir wx.Degrees) wx.Degrees {
+	return wx.NewDegrees(dir.Degrees() + d.degrees)
+}
+
+// WindToMagnetic converts a wind direction relative to true north,
+// as reported in a METAR, to one relative to magnetic north.
+func (d Declination) WindToMagnetic(dir wx.WindDirection) wx.WindDirection {
+	return wx.NewWindDirection(d.ToMagnetic(dir.Degrees()).Degrees())
+}
+
+// WindToTrue converts a wind direction relative to magnetic north,
+// as reported in an ATIS, to one relative to true north.
+func (d Declination) WindToTrue(dir wx.WindDirection) wx.WindDirection {
+	return wx.NewWindDirection(d.ToTrue(dir.Degrees()).Degrees())
+}
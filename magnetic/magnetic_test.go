@@ -0,0 +1,118 @@
+package magnetic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestNewDeclination(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		deg  float64
+		want float64
+		east bool
+		str  string
+	}{
+		{"east", 7.5, 7.5, true, "7.5°E"},
+		{"west", -12.6, -12.6, false, "12.6°W"},
+		{"zero", 0, 0, false, "0.0°E"},
+		{"wrapped east", 350, -10, false, "10.0°W"},
+		{"wrapped west", -190, 170, true, "170.0°E"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeclination(tc.deg)
+			if !tests.CloseEnough(d.Degrees(), tc.want, 1e-9) {
+				t.Errorf("expected %v, got %v", tc.want, d.Degrees())
+			}
+
+			if d.East() != tc.east {
+				t.Errorf("expected east %v, got %v", tc.east, d.East())
+			}
+
+			if d.String() != tc.str {
+				t.Errorf("expected %q, got %q", tc.str, d.String())
+			}
+		})
+	}
+}
+
+func TestDeclination_Convert(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		declination float64
+		trueNorth   float64
+		magnetic    float64
+	}{
+		{"east", 10, 90, 80},
+		{"west", -12, 90, 102},
+		{"across north east", 15, 5, 350},
+		{"across north west", -15, 350, 5},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDeclination(tc.declination)
+
+			if got := d.ToMagnetic(wx.NewDegrees(tc.trueNorth)).Degrees(); !tests.CloseEnough(got, tc.magnetic, 1e-9) {
+				t.Errorf("expected magnetic %v, got %v", tc.magnetic, got)
+			}
+
+			if got := d.ToTrue(wx.NewDegrees(tc.magnetic)).Degrees(); !tests.CloseEnough(got, tc.trueNorth, 1e-9) {
+				t.Errorf("expected true %v, got %v", tc.trueNorth, got)
+			}
+
+			if got := d.WindToMagnetic(wx.NewWindDirection(tc.trueNorth)).Degrees().Degrees(); !tests.CloseEnough(got, tc.magnetic, 1e-9) {
+				t.Errorf("expected magnetic wind %v, got %v", tc.magnetic, got)
+			}
+
+			if got := d.WindToTrue(wx.NewWindDirection(tc.magnetic)).Degrees().Degrees(); !tests.CloseEnough(got, tc.trueNorth, 1e-9) {
+				t.Errorf("expected true wind %v, got %v", tc.trueNorth, got)
+			}
+		})
+	}
+}
+
+func TestDeclinationAt(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC)
+
+	// Approximate declinations for mid-2025.
+	tt := []struct {
+		name     string
+		lat, lon float64
+		want     float64
+	}{
+		{"boulder", 40.015, -105.27, 7.8},
+		{"london", 51.5, -0.13, 1.0},
+		{"sydney", -33.87, 151.2, 12.8},
+		{"new york", 40.7, -74.0, -12.5},
+		{"tokyo", 35.7, 139.7, -7.9},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := DeclinationAt(tc.lat, tc.lon, wx.NewAltitude(0, wx.Meters), date)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(d.Degrees(), tc.want, 0.1) {
+				t.Errorf("expected %v, got %v", tc.want, d.Degrees())
+			}
+		})
+	}
+
+	if _, err := DeclinationAt(0, 0, wx.NewAltitude(0, wx.Meters), time.Date(2031, time.January, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("expected error after the model expired, got nil")
+	}
+}
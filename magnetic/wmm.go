@@ -0,0 +1,326 @@
+package magnetic
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-wx/wx"
+)
+
+const (
+	// referenceRadius is the geomagnetic reference radius in km.
+	referenceRadius = 6371.2
+
+	// wgs84A is the semi-major axis of the WGS 84 ellipsoid in km.
+	wgs84A = 6378.137
+
+	// wgs84F is the flattening of the WGS 84 ellipsoid.
+	wgs84F = 1 / 298.257223563
+
+	// modelLife is the number of years a model is valid for after
+	// its epoch.
+	modelLife = 5
+
+	// minAltitude and maxAltitude are the limits in km of the
+	// altitudes the model is valid for.
+	minAltitude = -1.0
+	maxAltitude = 850.0
+)
+
+//go:embed WMM.COF
+var wmmCOF []byte
+
+// WMM is the embedded World Magnetic Model.
+var WMM = func() Model {
+	m, err := ParseCoefficients(bytes.NewReader(wmmCOF))
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}()
+
+// coefficient is a pair of Gauss coefficients and their secular
+// variation for degree n and order m.
+type coefficient struct {
+	n, m       int
+	g, h       float64 // nT
+	gDot, hDot float64 // nT/year
+}
+
+// Model is a spherical harmonic model of the main geomagnetic
+// field, such as the World Magnetic Model.
+type Model struct {
+	name   string
+	epoch  float64
+	degree int
+	coefs  []coefficient
+}
+
+// ParseCoefficients parses a model from a coefficient file in the
+// WMM.COF format published by NOAA, so newer models can be loaded
+// without updating the package.
+func ParseCoefficients(r io.Reader) (Model, error) {
+	var m Model
+
+	s := bufio.NewScanner(r)
+	if !s.Scan() {
+		return Model{}, wx.NewWxErrKind(wx.ErrParse, "missing header", "magnetic model")
+	}
+
+	header := strings.Fields(s.Text())
+	if len(header) < 2 {
+		return Model{}, wx.NewWxErrKind(wx.ErrParse, "invalid header", "magnetic model")
+	}
+
+	epoch, err := strconv.ParseFloat(header[0], 64)
+	if err != nil {
+		return Model{}, wx.NewWxErrKind(wx.ErrParse, "invalid epoch", "magnetic model")
+	}
+
+	m.epoch, m.name = epoch, header[1]
+
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(line, "9999") {
+			break
+		}
+
+		f := strings.Fields(line)
+		if len(f) < 6 {
+			return Model{}, wx.NewWxErrKind(wx.ErrParse, "invalid coefficient line", "magnetic model")
+		}
+
+		var vals [6]float64
+		for i := range vals {
+			if vals[i], err = strconv.ParseFloat(f[i], 64); err != nil {
+				return Model{}, wx.NewWxErrKind(wx.ErrParse, "invalid coefficient", "magnetic model")
+			}
+		}
+
+		c := coefficient{
+			n: int(vals[0]), m: int(vals[1]),
+			g: vals[2], h: vals[3],
+			gDot: vals[4], hDot: vals[5],
+		}
+		if c.n < 1 || c.m < 0 || c.m > c.n {
+			return Model{}, wx.NewWxErrKind(wx.ErrParse, "invalid degree or order", "magnetic model")
+		}
+
+		if c.n > m.degree {
+			m.degree = c.n
+		}
+
+		m.coefs = append(m.coefs, c)
+	}
+
+	if err := s.Err(); err != nil {
+		return Model{}, wx.NewWxErrKind(wx.ErrParse, err.Error(), "magnetic model")
+	}
+
+	if len(m.coefs) == 0 {
+		return Model{}, wx.NewWxErrKind(wx.ErrParse, "no coefficients", "magnetic model")
+	}
+
+	return m, nil
+}
+
+// Name returns the name of the model, e.g. WMM-2025.
+func (m Model) Name() string {
+	return m.name
+}
+
+// Epoch returns the epoch of the model in decimal years.
+func (m Model) Epoch() float64 {
+	return m.epoch
+}
+
+// Field is the geomagnetic field at a point, in the geodetic
+// frame. The components are in nanotesla.
+type Field struct {
+	// X is the northward component.
+	X float64
+	// Y is the eastward component.
+	Y float64
+	// Z is the downward component.
+	Z float64
+	// Declination is the angle between true and magnetic north.
+	Declination Declination
+	// Inclination is the angle of the field below the horizontal
+	// in degrees.
+	Inclination float64
+}
+
+// H returns the horizontal intensity in nanotesla.
+func (f Field) H() float64 {
+	return math.Hypot(f.X, f.Y)
+}
+
+// F returns the total intensity in nanotesla.
+func (f Field) F() float64 {
+	return math.Sqrt(f.X*f.X + f.Y*f.Y + f.Z*f.Z)
+}
+
+// Field returns the geomagnetic field at a geodetic latitude and
+// longitude in degrees, an altitude above the WGS 84 ellipsoid and a
+// date. Altitudes below the ellipsoid are negative.
+//
+// An error is returned if the position is invalid, the altitude is
+// outside of -1 km to 850 km, or the date is outside of the five
+// years the model is valid for.
+func (m Model) Field(lat, lon float64, altitude wx.Altitude, date time.Time) (Field, error) {
+	alt := altitude.M() / 1000
+	if !altitude.Valid() || alt < minAltitude || alt > maxAltitude {
+		return Field{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "altitude out of range", "magnetic field")
+	}
+
+	// The horizontal field, and so the declination, is undefined
+	// at the geographic poles.
+	if math.IsNaN(lat) || math.IsNaN(lon) || math.Abs(lat) >= 90 {
+		return Field{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "invalid position", "magnetic field")
+	}
+
+	year := decimalYear(date)
+	if year < m.epoch || year >= m.epoch+modelLife {
+		return Field{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "date outside of model validity", "magnetic field")
+	}
+
+	phi, lambda := radians(lat), radians(lon)
+
+	// Convert the geodetic position to geocentric spherical
+	// coordinates.
+	e2 := wgs84F * (2 - wgs84F)
+	sinPhi := math.Sin(phi)
+	rc := wgs84A / math.Sqrt(1-e2*sinPhi*sinPhi)
+	p := (rc + alt) * math.Cos(phi)
+	z := (rc*(1-e2) + alt) * sinPhi
+	r := math.Hypot(p, z)
+	phiC := math.Asin(z / r)
+
+	x, y, zc := m.sphericalField(r, phiC, lambda, year-m.epoch)
+
+	// Rotate the field back into the geodetic frame.
+	d := phiC - phi
+	f := Field{
+		X: x*math.Cos(d) - zc*math.Sin(d),
+		Y: y,
+		Z: x*math.Sin(d) + zc*math.Cos(d),
+	}
+
+	f.Declination = NewDeclination(degrees(math.Atan2(f.Y, f.X)))
+	f.Inclination = degrees(math.Atan2(f.Z, f.H()))
+
+	return f, nil
+}
+
+// Declination returns the magnetic declination at a geodetic
+// latitude and longitude in degrees, an altitude above the WGS 84
+// ellipsoid and a date. See Field for the errors returned.
+func (m Model) Declination(lat, lon float64, altitude wx.Altitude, date time.Time) (Declination, error) {
+	f, err := m.Field(lat, lon, altitude, date)
+	if err != nil {
+		return Declination{}, err
+	}
+
+	return f.Declination, nil
+}
+
+// sphericalField returns the northward, eastward and downward field
+// components in nT at a geocentric radius in km, geocentric latitude
+// and longitude in radians, dt years after the model epoch.
+func (m Model) sphericalField(r, phi, lambda, dt float64) (x, y, z float64) {
+	p, dp := schmidtLegendre(m.degree, math.Sin(phi), math.Cos(phi))
+
+	ratio := referenceRadius / r
+	for _, c := range m.coefs {
+		g := c.g + dt*c.gDot
+		h := c.h + dt*c.hDot
+
+		rn := math.Pow(ratio, float64(c.n+2))
+		cosM, sinM := math.Cos(float64(c.m)*lambda), math.Sin(float64(c.m)*lambda)
+		gh := g*cosM + h*sinM
+
+		x += rn * gh * dp[c.n][c.m]
+		y += rn * float64(c.m) * (g*sinM - h*cosM) * p[c.n][c.m]
+		z -= rn * float64(c.n+1) * gh * p[c.n][c.m]
+	}
+
+	return x, y / math.Cos(phi), z
+}
+
+// schmidtLegendre returns the Schmidt semi-normalized associated
+// Legendre functions of cos(θ) up to degree n and their derivatives
+// with respect to the colatitude θ, given cos(θ) and sin(θ).
+func schmidtLegendre(n int, cosT, sinT float64) (p, dp [][]float64) {
+	p = make([][]float64, n+1)
+	dp = make([][]float64, n+1)
+	for i := range p {
+		p[i] = make([]float64, n+1)
+		dp[i] = make([]float64, n+1)
+	}
+
+	// Gauss-normalized functions by recursion.
+	p[0][0] = 1
+	for i := 1; i <= n; i++ {
+		for j := 0; j <= i; j++ {
+			switch {
+			case i == j:
+				p[i][j] = sinT * p[i-1][j-1]
+				dp[i][j] = sinT*dp[i-1][j-1] + cosT*p[i-1][j-1]
+			case i == 1:
+				p[i][j] = cosT * p[i-1][j]
+				dp[i][j] = cosT*dp[i-1][j] - sinT*p[i-1][j]
+			default:
+				k := float64((i-1)*(i-1)-j*j) / float64((2*i-1)*(2*i-3))
+				p[i][j] = cosT*p[i-1][j] - k*p[i-2][j]
+				dp[i][j] = cosT*dp[i-1][j] - sinT*p[i-1][j] - k*dp[i-2][j]
+			}
+		}
+	}
+
+	// Convert to Schmidt semi-normalization.
+	s := 1.0
+	for i := 1; i <= n; i++ {
+		s *= float64(2*i-1) / float64(i)
+		sm := s
+		for j := 0; j <= i; j++ {
+			if j > 0 {
+				d := 1.0
+				if j == 1 {
+					d = 2
+				}
+				sm *= math.Sqrt(float64(i-j+1) * d / float64(i+j))
+			}
+
+			p[i][j] *= sm
+			dp[i][j] *= sm
+		}
+	}
+
+	return p, dp
+}
+
+// decimalYear returns a date as a decimal year.
+func decimalYear(t time.Time) float64 {
+	t = t.UTC()
+	start := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	return float64(t.Year()) + float64(t.Sub(start))/float64(end.Sub(start))
+}
+
+// radians converts degrees to radians.
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// degrees converts radians to degrees.
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
@@ -0,0 +1,170 @@
+package magnetic
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+// loadWMM2020 loads the WMM-2020 coefficients, for which NOAA
+// published test values.
+func loadWMM2020(t *testing.T) Model {
+	t.Helper()
+
+	f, err := os.Open("testdata/WMM2020.COF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	m, err := ParseCoefficients(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+func TestModel_Field(t *testing.T) {
+	t.Parallel()
+
+	m := loadWMM2020(t)
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// Test values from the WMM2020 technical report.
+	tt := []struct {
+		name          string
+		lat, lon, alt float64
+		x, y, z       float64
+		d, i          float64
+	}{
+		{"north", 80, 0, 0, 6570.4, -146.3, 54606.0, -1.28, 83.14},
+		{"equator", 0, 120, 0, 39624.3, 109.9, -10932.5, 0.16, -15.42},
+		{"south", -80, 240, 0, 5940.6, 15772.1, -52480.8, 69.36, -72.20},
+		{"north aloft", 80, 0, 100, 6261.8, -185.5, 52429.1, -1.70, 83.19},
+		{"equator aloft", 0, 120, 100, 37636.7, 104.9, -10474.8, 0.16, -15.55},
+		{"south aloft", -80, 240, 100, 5744.9, 14799.5, -49969.4, 68.78, -72.37},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := m.Field(tc.lat, tc.lon, wx.NewAltitude(tc.alt, wx.Kilometers), epoch)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(f.X, tc.x, 0.1) || !tests.CloseEnough(f.Y, tc.y, 0.1) || !tests.CloseEnough(f.Z, tc.z, 0.1) {
+				t.Errorf("expected (%v, %v, %v), got (%.1f, %.1f, %.1f)", tc.x, tc.y, tc.z, f.X, f.Y, f.Z)
+			}
+
+			if !tests.CloseEnough(f.Declination.Degrees(), tc.d, 0.01) {
+				t.Errorf("expected declination %v, got %v", tc.d, f.Declination.Degrees())
+			}
+
+			if !tests.CloseEnough(f.Inclination, tc.i, 0.01) {
+				t.Errorf("expected inclination %v, got %v", tc.i, f.Inclination)
+			}
+
+			if !tests.CloseEnough(f.H()*f.H()+f.Z*f.Z, f.F()*f.F(), 1e-3) {
+				t.Errorf("expected H² + Z² = F², got H %v Z %v F %v", f.H(), f.Z, f.F())
+			}
+		})
+	}
+}
+
+func TestModel_FieldErrors(t *testing.T) {
+	t.Parallel()
+
+	m := loadWMM2020(t)
+	epoch := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name     string
+		lat, lon float64
+		alt      wx.Altitude
+		date     time.Time
+	}{
+		{"before epoch", 0, 0, wx.NewAltitude(0, wx.Meters), epoch.Add(-time.Second)},
+		{"expired", 0, 0, wx.NewAltitude(0, wx.Meters), epoch.AddDate(5, 0, 0)},
+		{"pole", 90, 0, wx.NewAltitude(0, wx.Meters), epoch},
+		{"latitude", -91, 0, wx.NewAltitude(0, wx.Meters), epoch},
+		{"too low", 0, 0, wx.NewAltitude(-2, wx.Kilometers), epoch},
+		{"too high", 0, 0, wx.NewAltitude(900, wx.Kilometers), epoch},
+		{"invalid altitude", 0, 0, wx.Altitude{}, epoch},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := m.Field(tc.lat, tc.lon, tc.alt, tc.date); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseCoefficients(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		cof   string
+		isErr bool
+	}{
+		{"valid", "2020.0 WMM-2020 12/10/2019\n1 0 -29404.5 0.0 6.7 0.0\n9999\n", false},
+		{"empty", "", true},
+		{"bad header", "WMM-2020\n", true},
+		{"bad epoch", "x WMM-2020 12/10/2019\n1 0 -29404.5 0.0 6.7 0.0\n", true},
+		{"no coefficients", "2020.0 WMM-2020 12/10/2019\n9999\n", true},
+		{"short line", "2020.0 WMM-2020 12/10/2019\n1 0 -29404.5\n", true},
+		{"bad number", "2020.0 WMM-2020 12/10/2019\n1 0 x 0.0 6.7 0.0\n", true},
+		{"bad order", "2020.0 WMM-2020 12/10/2019\n1 2 -29404.5 0.0 6.7 0.0\n", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCoefficients(strings.NewReader(tc.cof))
+			if (err != nil) != tc.isErr {
+				t.Errorf("expected error %v, got %v", tc.isErr, err)
+			}
+		})
+	}
+}
+
+func TestWMM(t *testing.T) {
+	t.Parallel()
+
+	if WMM.Name() != "WMM-2025" || WMM.Epoch() != 2025 {
+		t.Errorf("expected WMM-2025 at 2025, got %v at %v", WMM.Name(), WMM.Epoch())
+	}
+
+	if m := loadWMM2020(t); m.Name() != "WMM-2020" || m.Epoch() != 2020 {
+		t.Errorf("expected WMM-2020 at 2020, got %v at %v", m.Name(), m.Epoch())
+	}
+}
+
+func TestDecimalYear(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		date time.Time
+		want float64
+	}{
+		{"start", time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), 2025},
+		{"middle", time.Date(2025, time.July, 2, 12, 0, 0, 0, time.UTC), 2025.5},
+		{"leap year", time.Date(2024, time.July, 2, 0, 0, 0, 0, time.UTC), 2024.5},
+		{"time zone", time.Date(2025, time.January, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), 2025},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := decimalYear(tc.date); !tests.CloseEnough(got, tc.want, 1e-9) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package wx
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// quantityJSON is the JSON representation of a quantity, e.g.
+// {"value":29.92,"unit":"inHg"}. Valid is only written for invalid
+// quantities and Uncertainty only for quantities with a known
+// uncertainty.
+type quantityJSON struct {
+	Value       float64 `json:"value"`
+	Unit        string  `json:"unit"`
+	Valid       *bool   `json:"valid,omitempty"`
+	Uncertainty float64 `json:"uncertainty,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q Quantity[D]) MarshalJSON() ([]byte, error) {
+	j := quantityJSON{Value: q.measurement, Unit: q.unit.String(), Uncertainty: q.uncertainty}
+	if !q.valid {
+		j.Valid = new(bool)
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The quantity is invalid
+// if it was marshaled as invalid or the value is out of range for
+// the unit. An empty unit is the zero value of the unit. Like the
+// standard library, null is a no-op.
+func (q *Quantity[D]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		return nil
+	}
+
+	var j quantityJSON
+	if err := json.Unmarshal(b, &j); err != nil {
+		return NewWxErrKind(ErrParse, err.Error(), "unmarshal json")
+	}
+
+	unit, err := unmarshalUnit[D](j.Unit)
+	if err != nil {
+		return err
+	}
+
+	*q = NewQuantity(j.Value, unit)
+	q.unit = unit
+	q.measurement = j.Value
+	q.valid = q.valid && (j.Valid == nil || *j.Valid)
+	q.uncertainty = math.Abs(j.Uncertainty)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The text is the
+// measurement followed by the unit symbol, e.g. "29.92 inHg", and can
+// be read back by Parse.
+//
+// The text does not record validity, so an invalid quantity is only
+// invalid once unmarshaled if its value is out of range for the unit.
+func (q Quantity[D]) MarshalText() ([]byte, error) {
+	if _, ok := q.unit.definition(); !ok {
+		return nil, NewWxErrKind(ErrInvalidUnit, "invalid unit", "marshal text")
+	}
+
+	return []byte(strconv.FormatFloat(q.measurement, 'g', -1, 64) + " " + q.unit.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any
+// text accepted by Parse. A value that is out of range for the unit
+// is unmarshaled as an invalid quantity rather than an error.
+func (q *Quantity[D]) UnmarshalText(b []byte) error {
+	p, err := parse[D](string(b))
+	if err != nil {
+		return err
+	}
+
+	*q = p
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is
+// a validity byte and the big endian measurement followed by the unit
+// symbol. The uncertainty is not encoded.
+func (q Quantity[D]) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 9, 9+len(q.unit.String()))
+	if q.valid {
+		b[0] = 1
+	}
+	binary.BigEndian.PutUint64(b[1:], math.Float64bits(q.measurement))
+
+	return append(b, q.unit.String()...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (q *Quantity[D]) UnmarshalBinary(b []byte) error {
+	if len(b) < 9 {
+		return NewWxErrKind(ErrParse, fmt.Sprintf("%d bytes is too short", len(b)), "unmarshal binary")
+	}
+
+	unit, err := unmarshalUnit[D](string(b[9:]))
+	if err != nil {
+		return err
+	}
+
+	measurement := math.Float64frombits(binary.BigEndian.Uint64(b[1:9]))
+
+	*q = NewQuantity(measurement, unit)
+	q.unit = unit
+	q.measurement = measurement
+	q.valid = q.valid && b[0] == 1
+
+	return nil
+}
+
+// unmarshalUnit returns the unit for a symbol written by one of the
+// marshalers. An empty symbol is the zero value of the unit.
+func unmarshalUnit[D Dimension](symbol string) (D, error) {
+	var zero D
+	if symbol == "" {
+		return zero, nil
+	}
+
+	symbols, context := unitSymbols[D]()
+	unit, ok := lookupUnit(symbols, symbol)
+	if !ok {
+		return zero, NewWxErrKind(ErrInvalidUnit, fmt.Sprintf("unknown unit %q", symbol), context)
+	}
+
+	return unit, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Temp) MarshalJSON() ([]byte, error) {
+	return t.Quantity().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Temp) UnmarshalJSON(b []byte) error {
+	return (*Quantity[TempUnit])(t).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t Temp) MarshalText() ([]byte, error) {
+	return t.Quantity().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *Temp) UnmarshalText(b []byte) error {
+	return (*Quantity[TempUnit])(t).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (t Temp) MarshalBinary() ([]byte, error) {
+	return t.Quantity().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (t *Temp) UnmarshalBinary(b []byte) error {
+	return (*Quantity[TempUnit])(t).UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Distance) MarshalJSON() ([]byte, error) {
+	return d.Quantity().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Distance) UnmarshalJSON(b []byte) error {
+	return (*Quantity[DistanceUnit])(d).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Distance) MarshalText() ([]byte, error) {
+	return d.Quantity().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Distance) UnmarshalText(b []byte) error {
+	return (*Quantity[DistanceUnit])(d).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d Distance) MarshalBinary() ([]byte, error) {
+	return d.Quantity().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Distance) UnmarshalBinary(b []byte) error {
+	return (*Quantity[DistanceUnit])(d).UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p Pressure) MarshalJSON() ([]byte, error) {
+	return p.Quantity().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *Pressure) UnmarshalJSON(b []byte) error {
+	return (*Quantity[PressureUnit])(p).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p Pressure) MarshalText() ([]byte, error) {
+	return p.Quantity().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *Pressure) UnmarshalText(b []byte) error {
+	return (*Quantity[PressureUnit])(p).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (p Pressure) MarshalBinary() ([]byte, error) {
+	return p.Quantity().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (p *Pressure) UnmarshalBinary(b []byte) error {
+	return (*Quantity[PressureUnit])(p).UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Velocity) MarshalJSON() ([]byte, error) {
+	return v.Quantity().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Velocity) UnmarshalJSON(b []byte) error {
+	return (*Quantity[VelocityUnit])(v).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (v Velocity) MarshalText() ([]byte, error) {
+	return v.Quantity().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (v *Velocity) UnmarshalText(b []byte) error {
+	return (*Quantity[VelocityUnit])(v).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v Velocity) MarshalBinary() ([]byte, error) {
+	return v.Quantity().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Velocity) UnmarshalBinary(b []byte) error {
+	return (*Quantity[VelocityUnit])(v).UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler. Angles are written as a
+// number of degrees.
+func (d Degrees) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.degrees)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The angle is normalized
+// as by NewDegrees.
+func (d *Degrees) UnmarshalJSON(b []byte) error {
+	var deg float64
+	if err := json.Unmarshal(b, &deg); err != nil {
+		return NewWxErrKind(ErrParse, err.Error(), "unmarshal json")
+	}
+
+	*d = NewDegrees(deg)
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Degrees) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatFloat(d.degrees, 'g', -1, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The angle is
+// normalized as by NewDegrees.
+func (d *Degrees) UnmarshalText(b []byte) error {
+	deg, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return NewWxErrKind(ErrParse, fmt.Sprintf("invalid angle %q", b), "unmarshal text")
+	}
+
+	*d = NewDegrees(deg)
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is
+// the big endian angle in degrees.
+func (d Degrees) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, math.Float64bits(d.degrees))
+
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Degrees) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return NewWxErrKind(ErrParse, fmt.Sprintf("expected 8 bytes, got %d", len(b)), "unmarshal binary")
+	}
+
+	*d = NewDegrees(math.Float64frombits(binary.BigEndian.Uint64(b)))
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler. Wind directions are written
+// as a number of degrees from true north.
+func (wd WindDirection) MarshalJSON() ([]byte, error) {
+	return wd.degrees.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (wd *WindDirection) UnmarshalJSON(b []byte) error {
+	return wd.degrees.UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (wd WindDirection) MarshalText() ([]byte, error) {
+	return wd.degrees.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (wd *WindDirection) UnmarshalText(b []byte) error {
+	return wd.degrees.UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (wd WindDirection) MarshalBinary() ([]byte, error) {
+	return wd.degrees.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (wd *WindDirection) UnmarshalBinary(b []byte) error {
+	return wd.degrees.UnmarshalBinary(b)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a Altitude) MarshalJSON() ([]byte, error) {
+	return a.quantity().MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *Altitude) UnmarshalJSON(b []byte) error {
+	return (*Quantity[altitudeUnit])(a).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Altitude) MarshalText() ([]byte, error) {
+	return a.quantity().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Altitude) UnmarshalText(b []byte) error {
+	return (*Quantity[altitudeUnit])(a).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (a Altitude) MarshalBinary() ([]byte, error) {
+	return a.quantity().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (a *Altitude) UnmarshalBinary(b []byte) error {
+	return (*Quantity[altitudeUnit])(a).UnmarshalBinary(b)
+}
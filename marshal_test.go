@@ -0,0 +1,244 @@
+package wx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQuantity_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"pressure", NewPressure(29.92, InHg), `{"value":29.92,"unit":"inHg"}`},
+		{"temperature", NewTemp(-5, Celsius), `{"value":-5,"unit":"°C"}`},
+		{"distance", NewDistance(10, StatuteMiles), `{"value":10,"unit":"SM"}`},
+		{"velocity", NewVelocity(12, Kts), `{"value":12,"unit":"kts"}`},
+		{"invalid", NewDistance(-100, Feet), `{"value":-100,"unit":"ft","valid":false}`},
+		{"altitude below sea level", NewAltitude(-100, Feet), `{"value":-100,"unit":"ft"}`},
+		{"zero value", Velocity{}, `{"value":0,"unit":"","valid":false}`},
+		{"degrees", NewDegrees(-90), `270`},
+		{"wind direction", NewWindDirection(45), `45`},
+		{
+			"struct",
+			struct {
+				Altimeter Pressure `json:"altimeter"`
+			}{NewPressure(1013, HPa)},
+			`{"altimeter":{"value":1013,"unit":"hPa"}}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(b) != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, b)
+			}
+		})
+	}
+}
+
+func TestQuantity_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		json string
+		want Distance
+		err  bool
+	}{
+		{"valid", `{"value":10,"unit":"SM"}`, NewDistance(10, StatuteMiles), false},
+		{"marshaled as invalid", `{"value":10,"unit":"SM","valid":false}`, Distance{measurement: 10, unit: StatuteMiles}, false},
+		{"out of range", `{"value":-100,"unit":"ft"}`, NewDistance(-100, Feet), false},
+		{"empty unit", `{"value":0,"unit":""}`, Distance{}, false},
+		{"null", `null`, Distance{}, false},
+		{"unknown unit", `{"value":10,"unit":"parsnips"}`, Distance{}, true},
+		{"malformed", `{"value":"ten","unit":"SM"}`, Distance{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Distance
+			err := json.Unmarshal([]byte(tc.json), &got)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalJSON_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type observation struct {
+		Temp       Temp          `json:"temp"`
+		Visibility Distance      `json:"visibility"`
+		Elevation  Altitude      `json:"elevation"`
+		Altimeter  Pressure      `json:"altimeter"`
+		Speed      Velocity      `json:"speed"`
+		Direction  WindDirection `json:"direction"`
+		Heading    Degrees       `json:"heading"`
+	}
+
+	want := observation{
+		Temp:       NewTemp(-5, Celsius),
+		Visibility: NewDistance(-1, Meters),
+		Elevation:  NewAltitude(-1407, Feet),
+		Altimeter:  NewPressure(29.92, InHg),
+		Speed:      NewVelocity(4, Beaufort),
+		Direction:  NewWindDirection(270),
+		Heading:    NewDegrees(123.5),
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got observation
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestQuantity_MarshalText(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		v    interface{ MarshalText() ([]byte, error) }
+		want string
+		err  bool
+	}{
+		{"pressure", NewPressure(29.92, InHg), "29.92 inHg", false},
+		{"temperature", NewTemp(-5, Celsius), "-5 °C", false},
+		{"velocity", NewVelocity(60, Mpm), "60 m/min", false},
+		{"degrees", NewDegrees(370), "10", false},
+		{"wind direction", NewWindDirection(180.5), "180.5", false},
+		{"invalid unit", Velocity{}, "", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.v.MarshalText()
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if string(b) != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, b)
+			}
+		})
+	}
+}
+
+func TestQuantity_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		text string
+		want Distance
+		err  bool
+	}{
+		{"statute miles", "10 SM", NewDistance(10, StatuteMiles), false},
+		{"fraction", "1/2SM", NewDistance(0.5, StatuteMiles), false},
+		{"out of range", "-100 ft", NewDistance(-100, Feet), false},
+		{"unknown unit", "10 parsnips", Distance{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Distance
+			err := got.UnmarshalText([]byte(tc.text))
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+
+	// Text marshaling is used for map keys.
+	var m map[Velocity]int
+	if err := json.Unmarshal([]byte(`{"12 kts":1}`), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if m[NewVelocity(12, Kts)] != 1 {
+		t.Errorf("expected map key 12 kts, got %v", m)
+	}
+
+	var d Degrees
+	if err := d.UnmarshalText([]byte("north")); err == nil {
+		t.Errorf("expected error, got %v", d)
+	}
+}
+
+func TestQuantity_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		want Pressure
+	}{
+		{"valid", NewPressure(1013.25, HPa)},
+		{"invalid", Pressure{measurement: -1, unit: Psi}},
+		{"zero value", Pressure{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.want.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var got Pressure
+			if err := got.UnmarshalBinary(b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+
+	var p Pressure
+	if err := p.UnmarshalBinary([]byte{1, 2, 3}); err == nil {
+		t.Errorf("expected error for short input, got %v", p)
+	}
+
+	wd := NewWindDirection(225)
+	b, err := wd.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got WindDirection
+	if err := got.UnmarshalBinary(b); err != nil || got != wd {
+		t.Errorf("expected %v, got %v (%v)", wd, got, err)
+	}
+
+	if err := got.UnmarshalBinary(b[:4]); err == nil {
+		t.Errorf("expected error for short input")
+	}
+}
@@ -0,0 +1,73 @@
+package metar
+
+import "github.com/go-wx/wx"
+
+// Convert returns the report with every measurement converted to the
+// units of the system, e.g. for display in a user's preferred units.
+// Measurements that were not reported stay invalid.
+func (r Report) Convert(s wx.UnitSystem) Report {
+	r.Wind = r.Wind.Convert(s)
+	r.Visibility = r.Visibility.Convert(s)
+
+	if r.RVR != nil {
+		rvr := make([]RunwayVisualRange, len(r.RVR))
+		for i, v := range r.RVR {
+			rvr[i] = v.Convert(s)
+		}
+		r.RVR = rvr
+	}
+
+	if r.Sky != nil {
+		sky := make([]SkyCondition, len(r.Sky))
+		for i, sc := range r.Sky {
+			sky[i] = sc.Convert(s)
+		}
+		r.Sky = sky
+	}
+
+	r.Temp = r.Temp.Convert(s)
+	r.DewPoint = r.DewPoint.Convert(s)
+	r.Altimeter = r.Altimeter.Convert(s)
+
+	return r
+}
+
+// Convert returns the wind with its speeds converted to the velocity
+// unit of the system.
+func (w Wind) Convert(s wx.UnitSystem) Wind {
+	w.Speed = w.Speed.Convert(s)
+	w.Gust = w.Gust.Convert(s)
+
+	return w
+}
+
+// Convert returns the visibility converted to the distance unit of
+// the system.
+func (v Visibility) Convert(s wx.UnitSystem) Visibility {
+	v.Distance = v.Distance.Convert(s)
+
+	return v
+}
+
+// Convert returns the runway visual range converted to the range
+// unit of the system.
+func (r RunwayVisualRange) Convert(s wx.UnitSystem) RunwayVisualRange {
+	r.Range = convertTo(r.Range, s.Range)
+	r.Max = convertTo(r.Max, s.Range)
+
+	return r
+}
+
+// Convert returns the sky condition with its base converted to the
+// height unit of the system.
+func (sc SkyCondition) Convert(s wx.UnitSystem) SkyCondition {
+	sc.Base = convertTo(sc.Base, s.Height)
+
+	return sc
+}
+
+// convertTo converts a distance to the unit, using a system with only
+// that distance unit so unreported distances stay invalid.
+func convertTo(d wx.Distance, unit wx.DistanceUnit) wx.Distance {
+	return d.Convert(wx.UnitSystem{Distance: unit})
+}
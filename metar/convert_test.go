@@ -0,0 +1,61 @@
+package metar
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestReport_Convert(t *testing.T) {
+	t.Parallel()
+
+	r, err := Parse("METAR EGLL 121750Z 24008MPS 9999 R27L/0600 SCT012 BKN025 12/08 Q1013")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := r.Convert(wx.Aviation)
+
+	if c.Wind.Speed.Unit() != wx.Kts || !tests.CloseEnough(c.Wind.Speed.Mps(), 8, tests.Tolerance) {
+		t.Errorf("expected 8 mps in knots, got %v", c.Wind.Speed)
+	}
+
+	if c.Wind.Gusting() {
+		t.Errorf("expected no gust, got %v", c.Wind.Gust)
+	}
+
+	if c.Visibility.Distance.Unit() != wx.StatuteMiles || !c.Visibility.GreaterThan {
+		t.Errorf("expected visibility in statute miles, got %+v", c.Visibility)
+	}
+
+	if c.RVR[0].Range.Unit() != wx.Feet || !tests.CloseEnough(c.RVR[0].Range.M(), 600, tests.Tolerance) {
+		t.Errorf("expected 600 m RVR in feet, got %v", c.RVR[0].Range)
+	}
+
+	if c.RVR[0].Variable() {
+		t.Errorf("expected a fixed RVR, got %+v", c.RVR[0])
+	}
+
+	if c.Sky[0].Base.Unit() != wx.Feet || c.Sky[1].Base.FT() != 2500 {
+		t.Errorf("unexpected sky %+v", c.Sky)
+	}
+
+	if c.Temp.Unit() != wx.Celsius || c.Temp.C() != 12 {
+		t.Errorf("expected 12 °C, got %v", c.Temp)
+	}
+
+	if c.Altimeter.Unit() != wx.InHg || !tests.CloseEnough(c.Altimeter.HPa(), 1013, tests.Tolerance) {
+		t.Errorf("expected 1013 hPa in inHg, got %v", c.Altimeter)
+	}
+
+	// The original report is unchanged.
+	if r.RVR[0].Range.Unit() != wx.Meters || r.Wind.Speed.Unit() != wx.Mps {
+		t.Errorf("expected original report in meters, got %+v", r)
+	}
+
+	m := r.Convert(wx.Metric)
+	if m.Sky[0].Base.Unit() != wx.Meters || m.Wind.Speed.Unit() != wx.Kph {
+		t.Errorf("expected metric units, got %+v", m)
+	}
+}
@@ -0,0 +1,346 @@
+package metar
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-wx/wx"
+)
+
+// reportableMiles are the statute mile visibilities that may be
+// reported, in ascending order (FMH-1, table 6-1).
+var reportableMiles = []float64{
+	0, 1.0 / 16, 1.0 / 8, 3.0 / 16, 1.0 / 4, 5.0 / 16, 3.0 / 8, 1.0 / 2, 5.0 / 8, 3.0 / 4, 7.0 / 8,
+	1, 1 + 1.0/8, 1 + 1.0/4, 1 + 3.0/8, 1 + 1.0/2, 1 + 5.0/8, 1 + 3.0/4, 1 + 7.0/8,
+	2, 2 + 1.0/4, 2 + 1.0/2, 2 + 3.0/4,
+	3, 4, 5, 6, 7, 8, 9, 10, 15, 20, 25, 30, 35, 40, 45, 50, 55, 60, 65, 70, 75, 80, 85, 90, 95,
+}
+
+// epsilon absorbs floating point error before values are truncated
+// to their reportable resolution.
+const epsilon = 1e-6
+
+// Encode formats a report as a METAR or SPECI.
+//
+// Groups are written in the units carried by the report values:
+// a visibility in statute miles is written as SM and any other
+// unit as meters, an altimeter in inHg is written as an A group and
+// any other unit as a whole hPa Q group, and wind speeds in mps are
+// written as MPS and any other unit as KT. Values are rounded to
+// their reportable resolution; invalid values are omitted.
+func Encode(r Report) (string, error) {
+	if !isStation(r.Station) {
+		return "", wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid station identifier", "metar")
+	}
+
+	t := r.Time
+	if t.Day < 1 || t.Day > 31 || t.Hour < 0 || t.Hour > 24 || t.Minute < 0 || t.Minute > 59 {
+		return "", wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid observation time", "metar")
+	}
+
+	typ := r.Type
+	if typ == "" {
+		typ = TypeMETAR
+	}
+
+	groups := []string{typ}
+	if r.Corrected {
+		groups = append(groups, "COR")
+	}
+
+	groups = append(groups, r.Station, fmt.Sprintf("%02d%02d%02dZ", t.Day, t.Hour, t.Minute))
+	if r.Auto {
+		groups = append(groups, "AUTO")
+	}
+
+	if w := EncodeWind(r.Wind); w != "" {
+		groups = append(groups, w)
+	}
+
+	if r.Wind.HasVariableRange {
+		groups = append(groups, fmt.Sprintf("%03.0fV%03.0f",
+			encodeDirection(r.Wind.VariableFrom), encodeDirection(r.Wind.VariableTo)))
+	}
+
+	if r.Visibility.CAVOK {
+		groups = append(groups, "CAVOK")
+	} else {
+		if v := EncodeVisibility(r.Visibility); v != "" {
+			groups = append(groups, v)
+		}
+
+		for _, rvr := range r.RVR {
+			groups = append(groups, EncodeRVR(rvr))
+		}
+
+		for _, w := range r.Weather {
+			groups = append(groups, EncodeWeather(w))
+		}
+
+		for _, s := range r.Sky {
+			groups = append(groups, EncodeSky(s))
+		}
+	}
+
+	if r.Temp.Valid() {
+		td := ""
+		if r.DewPoint.Valid() {
+			td = encodeTemp(r.DewPoint)
+		}
+
+		groups = append(groups, encodeTemp(r.Temp)+"/"+td)
+	}
+
+	if r.Altimeter.Valid() {
+		groups = append(groups, encodeAltimeter(r.Altimeter))
+	}
+
+	if r.Remarks != "" {
+		groups = append(groups, "RMK", r.Remarks)
+	}
+
+	return strings.Join(groups, " "), nil
+}
+
+// EncodeWind formats a wind group. Directions are rounded to the
+// nearest ten degrees (north is 360) and speeds to whole units; a
+// speed that rounds to zero is reported as calm (00000KT). An empty
+// string is returned if the wind speed is invalid.
+func EncodeWind(w Wind) string {
+	if !w.Speed.Valid() {
+		return ""
+	}
+
+	suffix := "KT"
+	speed := w.Speed.Kts()
+	gust := w.Gust.Kts()
+	if w.Speed.Unit() == wx.Mps {
+		suffix = "MPS"
+		speed = w.Speed.Mps()
+		gust = w.Gust.Mps()
+	}
+
+	s := roundHalfUp(speed)
+	if s == 0 {
+		return "00000" + suffix
+	}
+
+	dir := "VRB"
+	if !w.Variable {
+		dir = fmt.Sprintf("%03.0f", encodeDirection(w.Direction))
+	}
+
+	g := ""
+	if w.Gusting() {
+		g = fmt.Sprintf("G%02.0f", roundHalfUp(gust))
+	}
+
+	return fmt.Sprintf("%s%02.0f%s%s", dir, s, g, suffix)
+}
+
+// encodeDirection rounds a direction to the nearest ten degrees
+// with north reported as 360.
+func encodeDirection(wd wx.WindDirection) float64 {
+	d := roundHalfUp(wd.Degrees().Degrees()/10) * 10
+	if d == 0 {
+		return 360
+	}
+
+	return d
+}
+
+// EncodeVisibility formats a prevailing visibility group. Values are
+// rounded down to the next lower reportable value. An empty string
+// is returned if the distance is invalid.
+func EncodeVisibility(v Visibility) string {
+	if v.CAVOK {
+		return "CAVOK"
+	}
+
+	if !v.Distance.Valid() {
+		return ""
+	}
+
+	if v.Distance.Unit() != wx.StatuteMiles {
+		m := reportableMeters(v.Distance.M())
+		if m >= 9999 {
+			return "9999"
+		}
+
+		return fmt.Sprintf("%04.0f", m)
+	}
+
+	prefix := ""
+	switch {
+	case v.LessThan:
+		prefix = "M"
+	case v.GreaterThan:
+		prefix = "P"
+	}
+
+	return prefix + formatMiles(reportableStatuteMiles(v.Distance.SM())) + "SM"
+}
+
+// reportableStatuteMiles rounds a visibility down to the next lower
+// reportable statute mile value.
+func reportableStatuteMiles(sm float64) float64 {
+	r := reportableMiles[0]
+	for _, v := range reportableMiles {
+		if v > sm+epsilon {
+			break
+		}
+		r = v
+	}
+
+	return r
+}
+
+// reportableMeters rounds a visibility down to the next lower
+// reportable value in meters (ICAO Annex 3): steps of 50 m below
+// 800 m, 100 m below 5 km and 1000 m below 10 km.
+func reportableMeters(m float64) float64 {
+	switch {
+	case m < 800:
+		return math.Floor(m/50+epsilon) * 50
+	case m < 5000:
+		return math.Floor(m/100+epsilon) * 100
+	case m < 10000:
+		return math.Floor(m/1000+epsilon) * 1000
+	}
+
+	return 9999
+}
+
+// formatMiles formats a reportable statute mile value as a whole
+// number, a fraction or a whole number and fraction (e.g. "1 1/2").
+func formatMiles(sm float64) string {
+	whole := math.Floor(sm + epsilon)
+	frac := sm - whole
+
+	if frac < epsilon {
+		return fmt.Sprintf("%.0f", whole)
+	}
+
+	sixteenths := int(math.Round(frac * 16))
+	den := 16
+	for sixteenths%2 == 0 {
+		sixteenths /= 2
+		den /= 2
+	}
+
+	if whole == 0 {
+		return fmt.Sprintf("%d/%d", sixteenths, den)
+	}
+
+	return fmt.Sprintf("%.0f %d/%d", whole, sixteenths, den)
+}
+
+// EncodeRVR formats a runway visual range group. Ranges in feet are
+// written with the FT suffix and all other units as meters.
+func EncodeRVR(r RunwayVisualRange) string {
+	feet := r.Range.Unit() == wx.Feet
+	value := func(d wx.Distance) float64 {
+		if feet {
+			return d.FT()
+		}
+
+		return d.M()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "R%s/", r.Runway)
+
+	b.WriteString(rvrBound(r.LessThan, r.GreaterThan))
+	fmt.Fprintf(&b, "%04.0f", math.Round(value(r.Range)))
+
+	if r.Variable() {
+		b.WriteString("V" + rvrBound(r.MaxLessThan, r.MaxGreaterThan))
+		fmt.Fprintf(&b, "%04.0f", math.Round(value(r.Max)))
+	}
+
+	if feet {
+		b.WriteString("FT")
+	}
+
+	if r.Trend != "" {
+		b.WriteString("/" + r.Trend)
+	}
+
+	return b.String()
+}
+
+// rvrBound returns the M or P prefix of a runway visual range value.
+func rvrBound(lessThan, greaterThan bool) string {
+	switch {
+	case lessThan:
+		return "M"
+	case greaterThan:
+		return "P"
+	}
+
+	return ""
+}
+
+// EncodeWeather formats a present weather group.
+func EncodeWeather(w Weather) string {
+	return w.Intensity + w.Descriptor + strings.Join(w.Phenomena, "")
+}
+
+// EncodeSky formats a sky condition group. Bases are rounded to the
+// nearest reportable increment: 100 ft up to 5,000 ft, 500 ft up to
+// 10,000 ft and 1,000 ft above that.
+func EncodeSky(s SkyCondition) string {
+	switch s.Cover {
+	case Clear, SkyClear, NoSignificantCloud, NoCloudDetected:
+		return s.Cover
+	}
+
+	base := "///"
+	if s.Base.Valid() {
+		ft := s.Base.FT()
+
+		step := 100.0
+		switch {
+		case ft > 10000:
+			step = 1000
+		case ft > 5000:
+			step = 500
+		}
+
+		base = fmt.Sprintf("%03.0f", roundHalfUp(ft/step)*step/100)
+	}
+
+	return s.Cover + base + s.CloudType
+}
+
+// encodeTemp formats a temperature as whole degrees Celsius with an
+// M prefix for negative values. Values from -0.5 to -0.1 are
+// reported as M00.
+func encodeTemp(t wx.Temp) string {
+	c := t.C()
+	r := roundHalfUp(c)
+
+	if math.Signbit(c) && r <= 0 {
+		return fmt.Sprintf("M%02.0f", math.Abs(r))
+	}
+
+	return fmt.Sprintf("%02.0f", r)
+}
+
+// encodeAltimeter formats an altimeter setting. Pressures in inHg
+// are truncated to hundredths and written as an A group; all other
+// units are truncated to whole hPa and written as a Q group.
+func encodeAltimeter(p wx.Pressure) string {
+	if p.Unit() == wx.InHg {
+		return fmt.Sprintf("A%04.0f", math.Floor(p.InHg()*100+epsilon))
+	}
+
+	return fmt.Sprintf("Q%04.0f", math.Floor(p.HPa()+epsilon))
+}
+
+// roundHalfUp rounds to the nearest whole number with halves
+// rounded towards positive infinity.
+func roundHalfUp(f float64) float64 {
+	return math.Floor(f + 0.5)
+}
@@ -0,0 +1,226 @@
+package metar
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	r := Report{
+		Station: "KJFK",
+		Time:    ObservationTime{Day: 12, Hour: 17, Minute: 51},
+		Auto:    true,
+		Wind: Wind{
+			Direction: wx.NewWindDirection(268),
+			Speed:     wx.NewVelocity(14.6, wx.Kts),
+			Gust:      wx.NewVelocity(25.2, wx.Kts),
+		},
+		Visibility: Visibility{Distance: wx.NewDistance(1.6, wx.StatuteMiles)},
+		Weather:    []Weather{{Intensity: "-", Descriptor: "SH", Phenomena: []string{"RA"}}},
+		Sky: []SkyCondition{
+			{Cover: Few, Base: wx.NewDistance(820, wx.Feet)},
+			{Cover: Broken, Base: wx.NewDistance(1500, wx.Feet), CloudType: "CB"},
+		},
+		Temp:      wx.NewTemp(-4.6, wx.Celsius),
+		DewPoint:  wx.NewTemp(-0.3, wx.Celsius),
+		Altimeter: wx.NewPressure(29.929, wx.InHg),
+		Remarks:   "AO2",
+	}
+
+	want := "METAR KJFK 121751Z AUTO 27015G25KT 1 1/2SM -SHRA FEW008 BKN015CB M05/M00 A2992 RMK AO2"
+
+	got, err := Encode(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	raws := []string{
+		"METAR KJFK 121751Z 27015G25KT 240V300 1 1/2SM R04R/2400FT -SHRA BR FEW008 BKN015CB OVC030 M05/M08 A2992 RMK AO2",
+		"SPECI EGLL 010950Z AUTO VRB03KT CAVOK 12/M01 Q1013",
+		"METAR UUEE 010930Z 24005MPS 0800 R24L/0550V0700/U FG VV002 M02/M02 Q1021",
+		"METAR KDEN 011953Z 00000KT P6SM CLR 15/ A3001",
+		"METAR KORD 011951Z 36010KT 1/2SM R27/1000V1800FT/U FG OVC002 02/01 A2990",
+		"METAR KBOS 011954Z 09008KT 10SM OVC020 12/M00 A3012",
+		"METAR EDDF 010950Z 27004KT 1200 R06/P1500V2000 BR OVC003 05/05 Q1018",
+		"METAR KSEA 011953Z 18005KT 1/4SM R16L/M0300VP6000FT FG VV001 08/08 A3002",
+	}
+
+	for _, raw := range raws {
+		t.Run(raw, func(t *testing.T) {
+			r, err := Parse(raw)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got, err := Encode(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != raw {
+				t.Errorf("expected %q, got %q", raw, got)
+			}
+		})
+	}
+}
+
+func TestEncode_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name   string
+		report Report
+	}{
+		{"missing station", Report{Time: ObservationTime{Day: 1}}},
+		{"missing time", Report{Station: "KJFK"}},
+		{"invalid minute", Report{Station: "KJFK", Time: ObservationTime{Day: 1, Minute: 60}}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Encode(tc.report); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestEncodeWind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		wind Wind
+		want string
+	}{
+		{"calm", Wind{Speed: wx.NewVelocity(0.4, wx.Kts)}, "00000KT"},
+		{"north is 360", Wind{Direction: wx.NewWindDirection(3), Speed: wx.NewVelocity(8, wx.Kts)}, "36008KT"},
+		{"variable", Wind{Variable: true, Speed: wx.NewVelocity(2.5, wx.Kts)}, "VRB03KT"},
+		{"converted to knots", Wind{Direction: wx.NewWindDirection(90), Speed: wx.NewVelocity(11.5, wx.Mph)}, "09010KT"},
+		{"meters per second", Wind{Direction: wx.NewWindDirection(90), Speed: wx.NewVelocity(4, wx.Mps)}, "09004MPS"},
+		{"three digit speed", Wind{Direction: wx.NewWindDirection(90), Speed: wx.NewVelocity(105, wx.Kts)}, "090105KT"},
+		{"invalid speed", Wind{Speed: wx.NewVelocity(-1, wx.Kts)}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EncodeWind(tc.wind); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeVisibility(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		vis  Visibility
+		want string
+	}{
+		{"rounds down miles", Visibility{Distance: wx.NewDistance(2.9, wx.StatuteMiles)}, "2 3/4SM"},
+		{"fraction", Visibility{Distance: wx.NewDistance(0.3, wx.StatuteMiles)}, "1/4SM"},
+		{"less than", Visibility{Distance: wx.NewDistance(0.25, wx.StatuteMiles), LessThan: true}, "M1/4SM"},
+		{"greater than", Visibility{Distance: wx.NewDistance(6, wx.StatuteMiles), GreaterThan: true}, "P6SM"},
+		{"small meters", Visibility{Distance: wx.NewDistance(780, wx.Meters)}, "0750"},
+		{"meters", Visibility{Distance: wx.NewDistance(2.45, wx.Kilometers)}, "2400"},
+		{"large meters", Visibility{Distance: wx.NewDistance(7800, wx.Meters)}, "7000"},
+		{"ten kilometers", Visibility{Distance: wx.NewDistance(12, wx.Kilometers)}, "9999"},
+		{"cavok", Visibility{CAVOK: true}, "CAVOK"},
+		{"invalid", Visibility{}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EncodeVisibility(tc.vis); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeSky(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		sky  SkyCondition
+		want string
+	}{
+		{"clear", SkyCondition{Cover: Clear}, "CLR"},
+		{"hundreds", SkyCondition{Cover: Scattered, Base: wx.NewDistance(2449, wx.Feet)}, "SCT024"},
+		{"five hundreds", SkyCondition{Cover: Broken, Base: wx.NewDistance(7300, wx.Feet)}, "BKN075"},
+		{"thousands", SkyCondition{Cover: Overcast, Base: wx.NewDistance(12400, wx.Feet)}, "OVC120"},
+		{"meters", SkyCondition{Cover: Few, Base: wx.NewDistance(300, wx.Meters), CloudType: "TCU"}, "FEW010TCU"},
+		{"unknown base", SkyCondition{Cover: Overcast}, "OVC///"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EncodeSky(tc.sky); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeTemp(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		temp wx.Temp
+		want string
+	}{
+		{"positive", wx.NewTemp(15.4, wx.Celsius), "15"},
+		{"half rounds up", wx.NewTemp(2.5, wx.Celsius), "03"},
+		{"negative", wx.NewTemp(-5.2, wx.Celsius), "M05"},
+		{"negative zero", wx.NewTemp(-0.4, wx.Celsius), "M00"},
+		{"signed zero", wx.NewTemp(math.Copysign(0, -1), wx.Celsius), "M00"},
+		{"fahrenheit", wx.NewTemp(32, wx.Fahrenheit), "00"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := encodeTemp(tc.temp); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeAltimeter(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		pressure wx.Pressure
+		want     string
+	}{
+		{"inHg", wx.NewPressure(29.92, wx.InHg), "A2992"},
+		{"inHg truncated", wx.NewPressure(30.019, wx.InHg), "A3001"},
+		{"hPa truncated", wx.NewPressure(1013.9, wx.HPa), "Q1013"},
+		{"pascals", wx.NewPressure(99850, wx.Pa), "Q0998"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := encodeAltimeter(tc.pressure); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package metar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-wx/wx"
+)
+
+var (
+	stationPattern  = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	timePattern     = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	windPattern     = regexp.MustCompile(`^(\d{3}|VRB)(\d{2,3})(?:G(\d{2,3}))?(KT|MPS|KMH)$`)
+	variablePattern = regexp.MustCompile(`^(\d{3})V(\d{3})$`)
+	smPattern       = regexp.MustCompile(`^([MP])?(?:(\d{1,2}) )?(\d{1,2}/\d{1,2}|\d{1,2})SM$`)
+	metersPattern   = regexp.MustCompile(`^(\d{4})(NDV)?$`)
+	rvrPattern      = regexp.MustCompile(`^R(\d{2}[LCR]?)/([MP])?(\d{4})(?:V([MP])?(\d{4}))?(FT)?/?([UDN])?$`)
+	weatherPattern  = regexp.MustCompile(`^(-|\+|VC)?(MI|PR|BC|DR|BL|SH|TS|FZ)?((?:DZ|RA|SN|SG|IC|PL|GR|GS|UP|BR|FG|FU|VA|DU|SA|HZ|PY|PO|SQ|FC|SS|DS)*)$`)
+	skyPattern      = regexp.MustCompile(`^(FEW|SCT|BKN|OVC|VV)(\d{3}|///)(CB|TCU|///)?$`)
+	tempPattern     = regexp.MustCompile(`^(M?\d{2})/(M?\d{2})?$`)
+	altPattern      = regexp.MustCompile(`^([AQ])(\d{4})$`)
+)
+
+// Wind is a decoded surface wind group (e.g. 27015G25KT).
+type Wind struct {
+	// Direction is the direction the wind is blowing from
+	// relative to true north.
+	Direction wx.WindDirection
+	// Variable is true if the direction was reported as VRB.
+	Variable bool
+	// Speed is the sustained wind speed.
+	Speed wx.Velocity
+	// Gust is the gust speed. It is invalid when no gust was reported.
+	Gust wx.Velocity
+	// VariableFrom is the start of a variable direction range
+	// (e.g. 240 in 240V300).
+	VariableFrom wx.WindDirection
+	// VariableTo is the end of a variable direction range.
+	VariableTo wx.WindDirection
+	// HasVariableRange is true if a variable direction range
+	// was reported.
+	HasVariableRange bool
+}
+
+// Calm returns true if the wind was reported as calm (00000KT).
+func (w Wind) Calm() bool {
+	return w.Speed.Valid() && w.Speed.Kts() == 0
+}
+
+// Gusting returns true if a gust speed was reported.
+func (w Wind) Gusting() bool {
+	return w.Gust.Valid()
+}
+
+// ParseWind decodes a wind group such as 27015G25KT, VRB03KT,
+// 00000KT or 24008MPS.
+func ParseWind(s string) (Wind, bool) {
+	m := windPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Wind{}, false
+	}
+
+	unit := windUnit(m[4])
+
+	var w Wind
+	if m[1] == "VRB" {
+		w.Variable = true
+	} else {
+		w.Direction = wx.NewWindDirection(atof(m[1]))
+	}
+
+	w.Speed = wx.NewVelocity(atof(m[2]), unit)
+	if m[3] != "" {
+		w.Gust = wx.NewVelocity(atof(m[3]), unit)
+	}
+
+	return w, true
+}
+
+// windUnit returns the velocity unit for a wind group suffix.
+func windUnit(s string) wx.VelocityUnit {
+	switch s {
+	case "MPS":
+		return wx.Mps
+	case "KMH":
+		return wx.Kph
+	}
+
+	return wx.Kts
+}
+
+// parseVariableDirection decodes a variable wind direction
+// group such as 240V300.
+func parseVariableDirection(s string) (from, to wx.WindDirection, ok bool) {
+	m := variablePattern.FindStringSubmatch(s)
+	if m == nil {
+		return from, to, false
+	}
+
+	return wx.NewWindDirection(atof(m[1])), wx.NewWindDirection(atof(m[2])), true
+}
+
+// Visibility is a decoded prevailing visibility group.
+type Visibility struct {
+	// Distance is the reported visibility in statute miles or meters.
+	Distance wx.Distance
+	// LessThan is true if the visibility is below the reported
+	// value (e.g. M1/4SM).
+	LessThan bool
+	// GreaterThan is true if the visibility exceeds the reported
+	// value (e.g. P6SM or 9999).
+	GreaterThan bool
+	// CAVOK is true if the report contained CAVOK.
+	CAVOK bool
+}
+
+// ParseVisibility decodes a visibility group such as 10SM, 1/2SM,
+// "1 1/2SM", M1/4SM, P6SM, 0800 or 9999.
+func ParseVisibility(s string) (Visibility, bool) {
+	if m := smPattern.FindStringSubmatch(s); m != nil {
+		miles, ok := parseFraction(m[3])
+		if !ok {
+			return Visibility{}, false
+		}
+
+		if m[2] != "" {
+			miles += atof(m[2])
+		}
+
+		return Visibility{
+			Distance:    wx.NewDistance(miles, wx.StatuteMiles),
+			LessThan:    m[1] == "M",
+			GreaterThan: m[1] == "P",
+		}, true
+	}
+
+	if m := metersPattern.FindStringSubmatch(s); m != nil {
+		meters := atof(m[1])
+
+		// 9999 means 10 km or more.
+		if meters == 9999 {
+			return Visibility{
+				Distance:    wx.NewDistance(10, wx.Kilometers),
+				GreaterThan: true,
+			}, true
+		}
+
+		return Visibility{Distance: wx.NewDistance(meters, wx.Meters)}, true
+	}
+
+	return Visibility{}, false
+}
+
+// RunwayVisualRange is a decoded runway visual range group.
+type RunwayVisualRange struct {
+	// Runway is the runway designator (e.g. 28L).
+	Runway string
+	// Range is the reported visual range, or the lower bound if
+	// the range is variable.
+	Range wx.Distance
+	// Max is the upper bound of a variable range. It is invalid
+	// when the range is not variable.
+	Max wx.Distance
+	// LessThan is true if the range is below the reported value,
+	// or the lower bound if the range is variable.
+	LessThan bool
+	// GreaterThan is true if the range exceeds the reported value,
+	// or the lower bound if the range is variable.
+	GreaterThan bool
+	// MaxLessThan is true if the upper bound of a variable range is
+	// below its reported value.
+	MaxLessThan bool
+	// MaxGreaterThan is true if the upper bound of a variable range
+	// exceeds its reported value.
+	MaxGreaterThan bool
+	// Trend is the tendency (U, D or N) or empty if not reported.
+	Trend string
+}
+
+// Variable returns true if the range was reported as variable.
+func (r RunwayVisualRange) Variable() bool {
+	return r.Max.Valid()
+}
+
+// ParseRVR decodes a runway visual range group such as
+// R28L/2400FT, R06/M0600 or R27/1000V1800FT/U.
+func ParseRVR(s string) (RunwayVisualRange, bool) {
+	m := rvrPattern.FindStringSubmatch(s)
+	if m == nil {
+		return RunwayVisualRange{}, false
+	}
+
+	unit := wx.Meters
+	if m[6] == "FT" {
+		unit = wx.Feet
+	}
+
+	r := RunwayVisualRange{
+		Runway:         m[1],
+		Range:          wx.NewDistance(atof(m[3]), unit),
+		LessThan:       m[2] == "M",
+		GreaterThan:    m[2] == "P",
+		MaxLessThan:    m[4] == "M",
+		MaxGreaterThan: m[4] == "P",
+		Trend:          m[7],
+	}
+
+	if m[5] != "" {
+		r.Max = wx.NewDistance(atof(m[5]), unit)
+	}
+
+	return r, true
+}
+
+// Weather is a decoded present weather group (e.g. -SHRA, +TSRAGR).
+type Weather struct {
+	// Intensity is "-", "+", "VC" or empty for moderate.
+	Intensity string
+	// Descriptor is the qualifier such as SH, TS or FZ.
+	Descriptor string
+	// Phenomena are the two letter weather codes in the order
+	// reported (e.g. RA, SN, BR).
+	Phenomena []string
+	// Raw is the original group.
+	Raw string
+}
+
+// ParseWeather decodes a present weather group.
+func ParseWeather(s string) (Weather, bool) {
+	m := weatherPattern.FindStringSubmatch(s)
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return Weather{}, false
+	}
+
+	w := Weather{
+		Intensity:  m[1],
+		Descriptor: m[2],
+		Raw:        s,
+	}
+
+	for i := 0; i+2 <= len(m[3]); i += 2 {
+		w.Phenomena = append(w.Phenomena, m[3][i:i+2])
+	}
+
+	return w, true
+}
+
+// Sky cover codes.
+const (
+	// Clear is no clouds below 12,000 ft at an automated station.
+	Clear = "CLR"
+	// SkyClear is no clouds reported by a human observer.
+	SkyClear = "SKC"
+	// NoSignificantCloud is no cloud of operational significance.
+	NoSignificantCloud = "NSC"
+	// NoCloudDetected is no cloud detected by an automated station.
+	NoCloudDetected = "NCD"
+	// Few is 1/8 to 2/8 sky coverage.
+	Few = "FEW"
+	// Scattered is 3/8 to 4/8 sky coverage.
+	Scattered = "SCT"
+	// Broken is 5/8 to 7/8 sky coverage.
+	Broken = "BKN"
+	// Overcast is 8/8 sky coverage.
+	Overcast = "OVC"
+	// VerticalVisibility is an obscured sky with a vertical visibility.
+	VerticalVisibility = "VV"
+)
+
+// SkyCondition is a decoded sky condition group (e.g. BKN015CB).
+type SkyCondition struct {
+	// Cover is the sky cover code (FEW, SCT, BKN, OVC, VV, CLR,
+	// SKC, NSC or NCD).
+	Cover string
+	// Base is the height of the layer base above ground level
+	// in feet. It is invalid for clear skies or an unknown base.
+	Base wx.Distance
+	// CloudType is CB or TCU when reported.
+	CloudType string
+}
+
+// Ceiling returns true if the layer constitutes a ceiling
+// (broken, overcast or an obscured sky).
+func (s SkyCondition) Ceiling() bool {
+	switch s.Cover {
+	case Broken, Overcast, VerticalVisibility:
+		return true
+	}
+
+	return false
+}
+
+// ParseSky decodes a sky condition group.
+func ParseSky(s string) (SkyCondition, bool) {
+	switch s {
+	case Clear, SkyClear, NoSignificantCloud, NoCloudDetected:
+		return SkyCondition{Cover: s}, true
+	}
+
+	m := skyPattern.FindStringSubmatch(s)
+	if m == nil {
+		return SkyCondition{}, false
+	}
+
+	sc := SkyCondition{Cover: m[1]}
+	if m[2] != "///" {
+		sc.Base = wx.NewDistance(atof(m[2])*100, wx.Feet)
+	}
+
+	if m[3] != "///" {
+		sc.CloudType = m[3]
+	}
+
+	return sc, true
+}
+
+// parseTemps decodes a temperature and dew point group such as
+// 15/08 or M05/M08. A missing dew point is returned as an
+// invalid temperature.
+func parseTemps(s string) (t, td wx.Temp, ok bool) {
+	m := tempPattern.FindStringSubmatch(s)
+	if m == nil {
+		return t, td, false
+	}
+
+	t = wx.NewTemp(parseSigned(m[1]), wx.Celsius)
+	if m[2] != "" {
+		td = wx.NewTemp(parseSigned(m[2]), wx.Celsius)
+	}
+
+	return t, td, true
+}
+
+// parseAltimeter decodes an altimeter group such as A2992
+// (inHg hundredths) or Q1013 (whole hPa).
+func parseAltimeter(s string) (wx.Pressure, bool) {
+	m := altPattern.FindStringSubmatch(s)
+	if m == nil {
+		return wx.Pressure{}, false
+	}
+
+	if m[1] == "A" {
+		return wx.NewPressure(atof(m[2])/100, wx.InHg), true
+	}
+
+	return wx.NewPressure(atof(m[2]), wx.HPa), true
+}
+
+// parseObservationTime decodes a DDHHMMZ group.
+func parseObservationTime(s string) (ObservationTime, bool) {
+	m := timePattern.FindStringSubmatch(s)
+	if m == nil {
+		return ObservationTime{}, false
+	}
+
+	o := ObservationTime{
+		Day:    int(atof(m[1])),
+		Hour:   int(atof(m[2])),
+		Minute: int(atof(m[3])),
+	}
+
+	if o.Day < 1 || o.Day > 31 || o.Hour > 24 || o.Minute > 59 {
+		return ObservationTime{}, false
+	}
+
+	return o, true
+}
+
+// isStation returns true if s looks like an ICAO station identifier.
+func isStation(s string) bool {
+	return stationPattern.MatchString(s)
+}
+
+// isWholeNumber returns true if s is a one or two digit number.
+func isWholeNumber(s string) bool {
+	if len(s) == 0 || len(s) > 2 {
+		return false
+	}
+
+	_, err := strconv.Atoi(s)
+
+	return err == nil
+}
+
+// parseFraction parses a whole number or a simple fraction (1/2).
+func parseFraction(s string) (float64, bool) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) == 1 {
+		return atof(s), true
+	}
+
+	d := atof(parts[1])
+	if d == 0 {
+		return 0, false
+	}
+
+	return atof(parts[0]) / d, true
+}
+
+// parseSigned parses a number where a leading M indicates a
+// negative value.
+func parseSigned(s string) float64 {
+	if strings.HasPrefix(s, "M") {
+		return -atof(s[1:])
+	}
+
+	return atof(s)
+}
+
+// atof parses a string of digits that has already been validated
+// by a pattern.
+func atof(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+
+	return f
+}
@@ -0,0 +1,305 @@
+package metar
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestParseWind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		input    string
+		ok       bool
+		variable bool
+		dir      float64
+		speed    string
+		gust     float64
+		calm     bool
+	}{
+		{name: "simple", input: "27015KT", ok: true, dir: 270, speed: "15.0 kts"},
+		{name: "gusting", input: "18020G35KT", ok: true, dir: 180, speed: "20.0 kts", gust: 35},
+		{name: "variable", input: "VRB03KT", ok: true, variable: true, speed: "3.0 kts"},
+		{name: "calm", input: "00000KT", ok: true, speed: "0.0 kts", calm: true},
+		{name: "meters per second", input: "24005MPS", ok: true, dir: 240, speed: "5.0 mps"},
+		{name: "kilometers per hour", input: "24018KMH", ok: true, dir: 240, speed: "18.0 kph"},
+		{name: "three digit speed", input: "090105G120KT", ok: true, dir: 90, speed: "105.0 kts", gust: 120},
+		{name: "not wind", input: "A2992", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ok := ParseWind(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if w.Variable != tc.variable || w.Calm() != tc.calm {
+				t.Errorf("unexpected wind state %+v", w)
+			}
+
+			if w.Direction.Degrees().Degrees() != tc.dir {
+				t.Errorf("expected direction %v, got %v", tc.dir, w.Direction.Degrees())
+			}
+
+			if w.Speed.String() != tc.speed {
+				t.Errorf("expected speed %v, got %v", tc.speed, w.Speed)
+			}
+
+			if w.Gusting() != (tc.gust != 0) || (tc.gust != 0 && w.Gust.Kts() != tc.gust) {
+				t.Errorf("expected gust %v, got %v", tc.gust, w.Gust)
+			}
+		})
+	}
+}
+
+func TestParseVisibility(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name        string
+		input       string
+		ok          bool
+		meters      float64
+		lessThan    bool
+		greaterThan bool
+	}{
+		{name: "whole miles", input: "10SM", ok: true, meters: 16093.44},
+		{name: "fraction", input: "1/2SM", ok: true, meters: 804.672},
+		{name: "mixed number", input: "1 1/2SM", ok: true, meters: 2414.016},
+		{name: "less than", input: "M1/4SM", ok: true, meters: 402.336, lessThan: true},
+		{name: "greater than", input: "P6SM", ok: true, meters: 9656.064, greaterThan: true},
+		{name: "meters", input: "0800", ok: true, meters: 800},
+		{name: "ten kilometers or more", input: "9999", ok: true, meters: 10000, greaterThan: true},
+		{name: "no directional variation", input: "9999NDV", ok: true, meters: 10000, greaterThan: true},
+		{name: "zero denominator", input: "1/0SM", ok: false},
+		{name: "not visibility", input: "BKN015", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			v, ok := ParseVisibility(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if !tests.CloseEnough(v.Distance.M(), tc.meters, 1e-2) {
+				t.Errorf("expected %v m, got %v", tc.meters, v.Distance.M())
+			}
+
+			if v.LessThan != tc.lessThan || v.GreaterThan != tc.greaterThan {
+				t.Errorf("unexpected bounds %+v", v)
+			}
+		})
+	}
+}
+
+func TestParseRVR(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		input    string
+		ok       bool
+		runway   string
+		rng      float64
+		max      float64
+		lessThan bool
+		maxP     bool
+		trend    string
+	}{
+		{name: "feet", input: "R28L/2400FT", ok: true, runway: "28L", rng: 2400},
+		{name: "meters less than", input: "R06/M0600", ok: true, runway: "06", rng: 600 * 3.280839895, lessThan: true},
+		{name: "variable with trend", input: "R27/1000V1800FT/U", ok: true, runway: "27", rng: 1000, max: 1800, trend: "U"},
+		{name: "variable greater than", input: "R06/P1500V2000", ok: true, runway: "06", rng: 1500 * 3.280839895, max: 2000 * 3.280839895},
+		{name: "variable upper greater than", input: "R06/1500VP2000", ok: true, runway: "06", rng: 1500 * 3.280839895, max: 2000 * 3.280839895, maxP: true},
+		{name: "not rvr", input: "RA", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, ok := ParseRVR(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if r.Runway != tc.runway || r.Trend != tc.trend || r.LessThan != tc.lessThan || r.MaxGreaterThan != tc.maxP {
+				t.Errorf("unexpected rvr %+v", r)
+			}
+
+			if !tests.CloseEnough(r.Range.FT(), tc.rng, 1e-6) {
+				t.Errorf("expected %v ft, got %v", tc.rng, r.Range.FT())
+			}
+
+			if r.Variable() != (tc.max != 0) || (tc.max != 0 && !tests.CloseEnough(r.Max.FT(), tc.max, 1e-6)) {
+				t.Errorf("expected max %v, got %v", tc.max, r.Max)
+			}
+		})
+	}
+}
+
+func TestParseWeather(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name       string
+		input      string
+		ok         bool
+		intensity  string
+		descriptor string
+		phenomena  []string
+	}{
+		{name: "light rain", input: "-RA", ok: true, intensity: "-", phenomena: []string{"RA"}},
+		{name: "thunderstorm", input: "+TSRAGR", ok: true, intensity: "+", descriptor: "TS", phenomena: []string{"RA", "GR"}},
+		{name: "vicinity showers", input: "VCSH", ok: true, intensity: "VC", descriptor: "SH"},
+		{name: "freezing fog", input: "FZFG", ok: true, descriptor: "FZ", phenomena: []string{"FG"}},
+		{name: "intensity only", input: "-", ok: false},
+		{name: "not weather", input: "OVC030", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w, ok := ParseWeather(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if w.Intensity != tc.intensity || w.Descriptor != tc.descriptor {
+				t.Errorf("unexpected weather %+v", w)
+			}
+
+			if len(w.Phenomena) != len(tc.phenomena) {
+				t.Fatalf("expected phenomena %v, got %v", tc.phenomena, w.Phenomena)
+			}
+
+			for i := range w.Phenomena {
+				if w.Phenomena[i] != tc.phenomena[i] {
+					t.Errorf("expected phenomena %v, got %v", tc.phenomena, w.Phenomena)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSky(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		input     string
+		ok        bool
+		cover     string
+		base      float64
+		cloudType string
+		ceiling   bool
+	}{
+		{name: "clear", input: "CLR", ok: true, cover: Clear},
+		{name: "few", input: "FEW008", ok: true, cover: Few, base: 800},
+		{name: "broken cumulonimbus", input: "BKN015CB", ok: true, cover: Broken, base: 1500, cloudType: "CB", ceiling: true},
+		{name: "vertical visibility", input: "VV002", ok: true, cover: VerticalVisibility, base: 200, ceiling: true},
+		{name: "unknown base", input: "OVC///", ok: true, cover: Overcast, ceiling: true},
+		{name: "not sky", input: "10SM", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s, ok := ParseSky(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if s.Cover != tc.cover || s.CloudType != tc.cloudType || s.Ceiling() != tc.ceiling {
+				t.Errorf("unexpected sky condition %+v", s)
+			}
+
+			if s.Base.Valid() != (tc.base != 0) || s.Base.FT() != tc.base {
+				t.Errorf("expected base %v, got %v", tc.base, s.Base)
+			}
+		})
+	}
+}
+
+func TestParseTemps(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name    string
+		input   string
+		ok      bool
+		temp    float64
+		dew     float64
+		dewSeen bool
+	}{
+		{name: "positive", input: "15/08", ok: true, temp: 15, dew: 8, dewSeen: true},
+		{name: "negative", input: "M05/M08", ok: true, temp: -5, dew: -8, dewSeen: true},
+		{name: "missing dew point", input: "03/", ok: true, temp: 3},
+		{name: "not temps", input: "1/2SM", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			temp, dew, ok := parseTemps(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if temp.C() != tc.temp || dew.Valid() != tc.dewSeen || (tc.dewSeen && dew.C() != tc.dew) {
+				t.Errorf("expected %v/%v, got %v/%v", tc.temp, tc.dew, temp, dew)
+			}
+		})
+	}
+}
+
+func TestParseAltimeter(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		input string
+		ok    bool
+		hPa   float64
+	}{
+		{name: "inHg", input: "A2992", ok: true, hPa: 1013.2},
+		{name: "hPa", input: "Q1013", ok: true, hPa: 1013},
+		{name: "not altimeter", input: "A29", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := parseAltimeter(tc.input)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if ok && !tests.CloseEnough(p.HPa(), tc.hPa, 0.1) {
+				t.Errorf("expected %v hPa, got %v", tc.hPa, p.HPa())
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Package metar decodes METAR and SPECI aviation routine weather
+// reports into the measurement types provided by the wx package.
+package metar
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-wx/wx"
+)
+
+// Report types.
+const (
+	// TypeMETAR is a routine aviation weather report.
+	TypeMETAR = "METAR"
+	// TypeSPECI is a special (unscheduled) aviation weather report.
+	TypeSPECI = "SPECI"
+)
+
+// ObservationTime is the day of month and UTC time of an observation.
+// METAR reports do not carry the month or year, so the time must be
+// resolved against a reference time to obtain a full timestamp.
+type ObservationTime struct {
+	Day    int
+	Hour   int
+	Minute int
+}
+
+// Resolve returns the observation time as a full UTC timestamp using
+// the most recent month at or before ref that contains the day of
+// the observation.
+func (o ObservationTime) Resolve(ref time.Time) time.Time {
+	ref = ref.UTC()
+	year, month := ref.Year(), ref.Month()
+
+	// Walk back until the observation is not in the future and the
+	// day exists in the month (e.g. day 31 in a 30 day month).
+	for i := 0; i < 12; i++ {
+		t := time.Date(year, month, o.Day, o.Hour, o.Minute, 0, 0, time.UTC)
+		if t.Day() == o.Day && !t.After(ref) {
+			return t
+		}
+
+		month--
+		if month < time.January {
+			month = time.December
+			year--
+		}
+	}
+
+	return time.Time{}
+}
+
+// Report is a decoded METAR or SPECI report.
+type Report struct {
+	// Type is either TypeMETAR or TypeSPECI.
+	Type string
+	// Station is the four letter ICAO station identifier.
+	Station string
+	// Time is the time of the observation.
+	Time ObservationTime
+	// Auto is true if the report is from a fully automated station.
+	Auto bool
+	// Corrected is true if the report is a correction (COR).
+	Corrected bool
+	// Wind is the surface wind group.
+	Wind Wind
+	// Visibility is the prevailing visibility.
+	Visibility Visibility
+	// RVR holds the runway visual range groups.
+	RVR []RunwayVisualRange
+	// Weather holds the present weather groups.
+	Weather []Weather
+	// Sky holds the sky condition groups in the order reported.
+	Sky []SkyCondition
+	// Temp is the air temperature, reported in Celsius.
+	Temp wx.Temp
+	// DewPoint is the dew point temperature, reported in Celsius.
+	DewPoint wx.Temp
+	// Altimeter is the altimeter setting in inHg (A group)
+	// or hPa (Q group).
+	Altimeter wx.Pressure
+	// Remarks is the raw text following RMK.
+	Remarks string
+	// Unparsed holds the body groups that could not be decoded.
+	Unparsed []string
+	// Raw is the original report text.
+	Raw string
+}
+
+// Parse decodes a raw METAR or SPECI report.
+//
+// The leading METAR or SPECI keyword is optional. Groups that are
+// not understood are collected in Report.Unparsed rather than
+// causing an error, but a missing station or time is an error.
+func Parse(raw string) (Report, error) {
+	r := Report{Raw: raw, Type: TypeMETAR}
+
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+	if len(fields) == 0 {
+		return r, wx.NewWxErrKind(wx.ErrParse, "empty report", "metar")
+	}
+
+	// Split off the remarks before decoding the body.
+	for i, f := range fields {
+		if f == "RMK" {
+			r.Remarks = strings.Join(fields[i+1:], " ")
+			fields = fields[:i]
+			break
+		}
+	}
+
+	p := &parser{fields: fields}
+
+	switch p.peek() {
+	case TypeMETAR, TypeSPECI:
+		r.Type = p.next()
+	}
+
+	if p.peek() == "COR" {
+		r.Corrected = true
+		p.next()
+	}
+
+	if !isStation(p.peek()) {
+		return r, wx.NewWxErrKind(wx.ErrParse, "missing station identifier", "metar")
+	}
+	r.Station = p.next()
+
+	t, ok := parseObservationTime(p.peek())
+	if !ok {
+		return r, wx.NewWxErrKind(wx.ErrParse, "missing observation time", "metar")
+	}
+	r.Time = t
+	p.next()
+
+	for !p.done() {
+		f := p.next()
+
+		switch {
+		case f == "AUTO":
+			r.Auto = true
+		case f == "COR":
+			r.Corrected = true
+		case f == "NIL":
+			return r, wx.NewWxErrKind(wx.ErrParse, "missing report (NIL)", "metar")
+		default:
+			if !r.decodeGroup(f, p) {
+				r.Unparsed = append(r.Unparsed, f)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// decodeGroup decodes a single body group into the report and
+// returns false if the group was not recognized.
+func (r *Report) decodeGroup(f string, p *parser) bool {
+	if w, ok := ParseWind(f); ok {
+		r.Wind = w
+		return true
+	}
+
+	if from, to, ok := parseVariableDirection(f); ok {
+		r.Wind.VariableFrom = from
+		r.Wind.VariableTo = to
+		r.Wind.HasVariableRange = true
+		return true
+	}
+
+	if f == "CAVOK" {
+		r.Visibility = Visibility{
+			Distance:    wx.NewDistance(10, wx.Kilometers),
+			GreaterThan: true,
+			CAVOK:       true,
+		}
+		return true
+	}
+
+	// Statute mile visibility may be split into a whole number and
+	// a fraction (e.g. "1 1/2SM").
+	if isWholeNumber(f) && strings.HasSuffix(p.peek(), "SM") {
+		if v, ok := ParseVisibility(f + " " + p.peek()); ok {
+			r.Visibility = v
+			p.next()
+			return true
+		}
+	}
+
+	if v, ok := ParseVisibility(f); ok {
+		r.Visibility = v
+		return true
+	}
+
+	if rvr, ok := ParseRVR(f); ok {
+		r.RVR = append(r.RVR, rvr)
+		return true
+	}
+
+	if s, ok := ParseSky(f); ok {
+		r.Sky = append(r.Sky, s)
+		return true
+	}
+
+	if t, td, ok := parseTemps(f); ok {
+		r.Temp = t
+		r.DewPoint = td
+		return true
+	}
+
+	if a, ok := parseAltimeter(f); ok {
+		r.Altimeter = a
+		return true
+	}
+
+	if w, ok := ParseWeather(f); ok {
+		r.Weather = append(r.Weather, w)
+		return true
+	}
+
+	return false
+}
+
+// parser walks the whitespace separated groups of a report.
+type parser struct {
+	fields []string
+	pos    int
+}
+
+// done returns true if there are no more groups.
+func (p *parser) done() bool {
+	return p.pos >= len(p.fields)
+}
+
+// peek returns the next group without consuming it.
+func (p *parser) peek() string {
+	if p.done() {
+		return ""
+	}
+
+	return p.fields[p.pos]
+}
+
+// next consumes and returns the next group.
+func (p *parser) next() string {
+	f := p.peek()
+	p.pos++
+
+	return f
+}
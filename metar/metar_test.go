@@ -0,0 +1,144 @@
+package metar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	raw := "METAR KJFK 121751Z 27015G25KT 240V300 1 1/2SM R04R/2400FT/D -SHRA BR " +
+		"FEW008 BKN015CB OVC030 M05/M08 A2992 RMK AO2 SLP132="
+
+	r, err := Parse(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Type != TypeMETAR || r.Station != "KJFK" {
+		t.Errorf("expected METAR KJFK, got %v %v", r.Type, r.Station)
+	}
+
+	if r.Time != (ObservationTime{Day: 12, Hour: 17, Minute: 51}) {
+		t.Errorf("unexpected time %+v", r.Time)
+	}
+
+	if r.Wind.Direction.Degrees().Degrees() != 270 || r.Wind.Speed.Kts() != 15 || r.Wind.Gust.Kts() != 25 {
+		t.Errorf("unexpected wind %+v", r.Wind)
+	}
+
+	if !r.Wind.HasVariableRange || r.Wind.VariableFrom.Degrees().Degrees() != 240 ||
+		r.Wind.VariableTo.Degrees().Degrees() != 300 {
+		t.Errorf("unexpected variable wind range %+v", r.Wind)
+	}
+
+	if r.Visibility.Distance.SM() != 1.5 {
+		t.Errorf("expected 1.5 SM visibility, got %v", r.Visibility.Distance)
+	}
+
+	if len(r.RVR) != 1 || r.RVR[0].Runway != "04R" || r.RVR[0].Range.FT() != 2400 || r.RVR[0].Trend != "D" {
+		t.Errorf("unexpected RVR %+v", r.RVR)
+	}
+
+	if len(r.Weather) != 2 || r.Weather[0].Raw != "-SHRA" || r.Weather[1].Raw != "BR" {
+		t.Errorf("unexpected weather %+v", r.Weather)
+	}
+
+	if len(r.Sky) != 3 || r.Sky[1].Cover != Broken || r.Sky[1].CloudType != "CB" || r.Sky[1].Base.FT() != 1500 {
+		t.Errorf("unexpected sky %+v", r.Sky)
+	}
+
+	if r.Temp.C() != -5 || r.DewPoint.C() != -8 {
+		t.Errorf("expected M05/M08, got %v/%v", r.Temp, r.DewPoint)
+	}
+
+	if !tests.CloseEnough(r.Altimeter.InHg(), 29.92, tests.Tolerance) {
+		t.Errorf("expected 29.92 inHg, got %v", r.Altimeter)
+	}
+
+	if r.Remarks != "AO2 SLP132" {
+		t.Errorf("unexpected remarks %q", r.Remarks)
+	}
+
+	if len(r.Unparsed) != 0 {
+		t.Errorf("unexpected unparsed groups %v", r.Unparsed)
+	}
+}
+
+func TestParse_International(t *testing.T) {
+	t.Parallel()
+
+	r, err := Parse("SPECI EGLL 010950Z AUTO VRB03KT CAVOK 12/M01 Q1013 NOSIG")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Type != TypeSPECI || !r.Auto {
+		t.Errorf("expected automated SPECI, got %+v", r)
+	}
+
+	if !r.Wind.Variable || r.Wind.Speed.Kts() != 3 {
+		t.Errorf("expected VRB03KT, got %+v", r.Wind)
+	}
+
+	if !r.Visibility.CAVOK || r.Visibility.Distance.KM() != 10 {
+		t.Errorf("expected CAVOK, got %+v", r.Visibility)
+	}
+
+	if r.Altimeter.HPa() != 1013 {
+		t.Errorf("expected 1013 hPa, got %v", r.Altimeter)
+	}
+
+	if len(r.Unparsed) != 1 || r.Unparsed[0] != "NOSIG" {
+		t.Errorf("expected NOSIG to be unparsed, got %v", r.Unparsed)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"missing station", "METAR 121751Z 27015KT"},
+		{"missing time", "METAR KJFK 27015KT"},
+		{"nil report", "METAR KJFK 121751Z NIL"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(tc.raw); err == nil {
+				t.Errorf("expected an error for %q", tc.raw)
+			}
+		})
+	}
+}
+
+func TestObservationTime_Resolve(t *testing.T) {
+	t.Parallel()
+
+	ref := time.Date(2023, time.March, 2, 12, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name string
+		obs  ObservationTime
+		want time.Time
+	}{
+		{"same day", ObservationTime{2, 11, 53}, time.Date(2023, time.March, 2, 11, 53, 0, 0, time.UTC)},
+		{"previous month", ObservationTime{28, 23, 53}, time.Date(2023, time.February, 28, 23, 53, 0, 0, time.UTC)},
+		{"skips short month", ObservationTime{31, 23, 53}, time.Date(2023, time.January, 31, 23, 53, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.obs.Resolve(ref); !got.Equal(tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package wx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Parse parses a quantity from a measurement followed by a unit, with
+// or without a space between them, e.g. "29.92 inHg", "1013hPa",
+// "-5 °C", "12 kts" or "1 1/2SM". Measurements may be decimals,
+// exponent notation such as "1e3", fractions or whole numbers
+// followed by a fraction.
+//
+// Units are matched against their symbols and common aliases, first
+// exactly and then ignoring case, so "KT", "kt" and "kts" are all
+// knots.
+//
+// An error wrapping ErrParse is returned if the string cannot be
+// parsed, or ErrInvalidUnit if the unit is not recognized. If the
+// measurement is out of range for the unit, e.g. a negative
+// distance, the quantity is returned along with a RangeError.
+func Parse[D Dimension](s string) (Quantity[D], error) {
+	q, err := parse[D](s)
+	if err != nil {
+		return q, err
+	}
+
+	_, context := unitSymbols[D]()
+
+	return q, checkQuantity(q.measurement, q.unit, context)
+}
+
+// parse parses a quantity like Parse, but a measurement that is out
+// of range for the unit is an invalid quantity rather than an error.
+func parse[D Dimension](s string) (Quantity[D], error) {
+	symbols, context := unitSymbols[D]()
+
+	num, sym := splitQuantity(s)
+	if num == "" {
+		return Quantity[D]{}, NewWxErrKind(ErrParse, fmt.Sprintf("missing measurement in %q", s), context)
+	}
+
+	if sym == "" {
+		return Quantity[D]{}, NewWxErrKind(ErrParse, fmt.Sprintf("missing unit in %q", s), context)
+	}
+
+	measurement, err := parseMeasurement(num)
+	if err != nil {
+		return Quantity[D]{}, NewWxErrKind(ErrParse, fmt.Sprintf("invalid measurement %q", num), context)
+	}
+
+	unit, ok := lookupUnit(symbols, sym)
+	if !ok {
+		return Quantity[D]{}, NewWxErrKind(ErrInvalidUnit, fmt.Sprintf("unknown unit %q", sym), context)
+	}
+
+	return NewQuantity(measurement, unit), nil
+}
+
+// ParseTemp parses a temperature such as "-5 °C" or "72F".
+// See Parse for the accepted formats.
+func ParseTemp(s string) (Temp, error) {
+	q, err := Parse[TempUnit](s)
+	return Temp(q), err
+}
+
+// ParseDistance parses a distance such as "10SM" or "1/2SM".
+// See Parse for the accepted formats.
+func ParseDistance(s string) (Distance, error) {
+	q, err := Parse[DistanceUnit](s)
+	return Distance(q), err
+}
+
+// ParseAltitude parses an altitude such as "-1407 ft" or "3000m".
+// See Parse for the accepted formats.
+func ParseAltitude(s string) (Altitude, error) {
+	q, err := Parse[altitudeUnit](s)
+	return Altitude(q), err
+}
+
+// ParsePressure parses a pressure such as "29.92 inHg" or "1013hPa".
+// See Parse for the accepted formats.
+func ParsePressure(s string) (Pressure, error) {
+	q, err := Parse[PressureUnit](s)
+	return Pressure(q), err
+}
+
+// ParseVelocity parses a velocity such as "12 kts" or "5 m/s".
+// See Parse for the accepted formats.
+func ParseVelocity(s string) (Velocity, error) {
+	q, err := Parse[VelocityUnit](s)
+	return Velocity(q), err
+}
+
+// Alternative unit symbols accepted when parsing, in addition to the
+// symbols of the registered units.
+var (
+	tempAliases = map[string]TempUnit{
+		"C": Celsius, "degC": Celsius, "celsius": Celsius,
+		"F": Fahrenheit, "degF": Fahrenheit, "fahrenheit": Fahrenheit,
+		"kelvin": Kelvin, "rankine": Rankine, "°R": Rankine,
+	}
+	distanceAliases = map[string]DistanceUnit{
+		"feet": Feet, "foot": Feet, "'": Feet,
+		"meters": Meters, "metres": Meters,
+		"kilometers": Kilometers, "kilometres": Kilometers,
+		"nmi": NauticalMiles,
+		"mi":  StatuteMiles, "miles": StatuteMiles,
+		"furlongs": Furlongs, "furlong": Furlongs,
+	}
+	pressureAliases = map[string]PressureUnit{
+		"mbar": Mb, "in Hg": InHg, "\"Hg": InHg, "mmHg": Torr,
+	}
+	velocityAliases = map[string]VelocityUnit{
+		"ft/s": Fps,
+		"kt":   Kts, "knots": Kts,
+		"km/h": Kph, "kmh": Kph,
+		"m/s": Mps,
+		"mpm": Mpm,
+	}
+)
+
+// unitSymbols returns the symbols and aliases of the units of the
+// dimension D and the error context for parsing it.
+func unitSymbols[D Dimension]() (map[string]D, string) {
+	var zero D
+
+	symbols := make(map[string]D)
+	add := func(symbol string, unit interface{}) {
+		symbols[symbol] = unit.(D)
+	}
+
+	switch interface{}(zero).(type) {
+	case TempUnit:
+		for t, def := range tempUnits {
+			add(def.symbol, TempUnit{t})
+		}
+		for s, u := range tempAliases {
+			add(s, u)
+		}
+		return symbols, "parse temperature"
+	case DistanceUnit:
+		for d, def := range distanceUnits {
+			add(def.symbol, DistanceUnit{d})
+		}
+		for s, u := range distanceAliases {
+			add(s, u)
+		}
+		return symbols, "parse distance"
+	case altitudeUnit:
+		for d, def := range distanceUnits {
+			add(def.symbol, altitudeUnit{d})
+		}
+		for s, u := range distanceAliases {
+			add(s, altitudeUnit{u.distanceType})
+		}
+		return symbols, "parse altitude"
+	case PressureUnit:
+		for p, def := range pressureUnits {
+			add(def.symbol, PressureUnit{p})
+		}
+		for s, u := range pressureAliases {
+			add(s, u)
+		}
+		return symbols, "parse pressure"
+	case VelocityUnit:
+		for v, def := range velocityUnits {
+			add(def.symbol, VelocityUnit{v})
+		}
+		for s, u := range velocityAliases {
+			add(s, u)
+		}
+		return symbols, "parse velocity"
+	}
+
+	return symbols, "parse"
+}
+
+// lookupUnit returns the unit for a symbol, matching it exactly if
+// possible and otherwise ignoring case.
+func lookupUnit[D Dimension](symbols map[string]D, symbol string) (D, bool) {
+	if u, ok := symbols[symbol]; ok {
+		return u, true
+	}
+
+	var match D
+	var found bool
+	for s, u := range symbols {
+		if !strings.EqualFold(s, symbol) {
+			continue
+		}
+
+		// A symbol that only matches units ignoring case is
+		// ambiguous if it matches more than one unit.
+		if found && u != match {
+			return match, false
+		}
+
+		match, found = u, true
+	}
+
+	return match, found
+}
+
+// splitQuantity splits a string into the measurement and the unit
+// symbol. The unit starts at the first letter or symbol that cannot
+// be part of a number, other than the exponent of a number such as
+// "1e3".
+func splitQuantity(s string) (num, sym string) {
+	s = strings.TrimSpace(s)
+
+	var i int
+	for {
+		j := strings.IndexFunc(s[i:], func(r rune) bool {
+			return !unicode.IsDigit(r) && !unicode.IsSpace(r) && !strings.ContainsRune("+-./", r)
+		})
+		if j < 0 {
+			return s, ""
+		}
+
+		i += j
+
+		n := exponentLen(s, i)
+		if n == 0 {
+			break
+		}
+
+		i += n
+	}
+
+	return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i:])
+}
+
+// exponentLen returns the length of the exponent marker at s[i], an
+// "e" or "E" and an optional sign between a digit and the digits of
+// the exponent, or 0 if there is none.
+func exponentLen(s string, i int) int {
+	if i == 0 || (s[i] != 'e' && s[i] != 'E') || !isDigit(s[i-1]) {
+		return 0
+	}
+
+	n := 1
+	if i+n < len(s) && (s[i+n] == '+' || s[i+n] == '-') {
+		n++
+	}
+
+	if i+n >= len(s) || !isDigit(s[i+n]) {
+		return 0
+	}
+
+	return n
+}
+
+// isDigit returns true if b is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// parseMeasurement parses a decimal, a fraction such as "1/2" or a
+// whole number followed by a fraction such as "1 1/2".
+func parseMeasurement(s string) (float64, error) {
+	if !strings.Contains(s, "/") {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	sign := 1.0
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+
+	var whole float64
+	fields := strings.Fields(s)
+	switch len(fields) {
+	case 1:
+	case 2:
+		w, err := strconv.ParseUint(fields[0], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		whole = float64(w)
+	default:
+		return 0, strconv.ErrSyntax
+	}
+
+	parts := strings.Split(fields[len(fields)-1], "/")
+	if len(parts) != 2 {
+		return 0, strconv.ErrSyntax
+	}
+
+	n, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil || d == 0 {
+		return 0, strconv.ErrSyntax
+	}
+
+	return sign * (whole + float64(n)/float64(d)), nil
+}
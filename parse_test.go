@@ -0,0 +1,210 @@
+package wx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		s     string
+		want  Quantity[VelocityUnit]
+		valid bool
+		err   bool
+	}{
+		{"knots", "12 kts", NewQuantity(12, Kts), true, false},
+		{"no space", "12KT", NewQuantity(12, Kts), true, false},
+		{"alias", "5 m/s", NewQuantity(5, Mps), true, false},
+		{"symbol", "60 m/min", NewQuantity(60, Mpm), true, false},
+		{"beaufort", "4 Bft", NewQuantity(4, Beaufort), true, false},
+		{"surrounding space", "  7 mph ", NewQuantity(7, Mph), true, false},
+		{"negative", "-3 kts", NewQuantity(-3, Kts), false, true},
+		{"unknown unit", "12 furlongs", Quantity[VelocityUnit]{}, false, true},
+		{"missing unit", "12", Quantity[VelocityUnit]{}, false, true},
+		{"missing measurement", "kts", Quantity[VelocityUnit]{}, false, true},
+		{"invalid measurement", "1.2.3 kts", Quantity[VelocityUnit]{}, false, true},
+		{"empty", "", Quantity[VelocityUnit]{}, false, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse[VelocityUnit](tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+
+			if got.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, got.Valid())
+			}
+		})
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParsePressure("29.92 bananas")
+
+	var wxErr Error
+	if !errors.As(err, &wxErr) {
+		t.Fatalf("expected wx.Error, got %T", err)
+	}
+
+	if want := `parse pressure: unknown unit "bananas"`; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
+
+func TestParseTemp(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		s    string
+		want Temp
+		err  bool
+	}{
+		{"celsius", "-5 °C", NewTemp(-5, Celsius), false},
+		{"no space", "-5°C", NewTemp(-5, Celsius), false},
+		{"letter only", "21C", NewTemp(21, Celsius), false},
+		{"fahrenheit", "72 °F", NewTemp(72, Fahrenheit), false},
+		{"kelvin", "273.15 K", NewTemp(273.15, Kelvin), false},
+		{"rankine", "491.67 °R", NewTemp(491.67, Rankine), false},
+		{"lower case", "300 k", NewTemp(300, Kelvin), false},
+		{"below absolute zero", "-300 °C", NewTemp(-300, Celsius), true},
+		{"unknown unit", "20 °X", Temp{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTemp(tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDistance(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		s    string
+		want float64
+		unit DistanceUnit
+		err  bool
+	}{
+		{"statute miles", "10SM", 10, StatuteMiles, false},
+		{"fraction", "1/2SM", 0.5, StatuteMiles, false},
+		{"whole and fraction", "1 1/2SM", 1.5, StatuteMiles, false},
+		{"fraction with space", "3/4 SM", 0.75, StatuteMiles, false},
+		{"feet", "5434 ft", 5434, Feet, false},
+		{"meters", "9999m", 9999, Meters, false},
+		{"nautical miles", "2.5 nm", 2.5, NauticalMiles, false},
+		{"kilometers", "12 KM", 12, Kilometers, false},
+		{"furlongs", "3 furlongs", 3, Furlongs, false},
+		{"exponent", "1e3 m", 1000, Meters, false},
+		{"signed exponent", "2.5E-1km", 0.25, Kilometers, false},
+		{"positive exponent", "1.2e+2 ft", 120, Feet, false},
+		{"below sea level", "-100 ft", -100, Feet, true},
+		{"negative fraction", "-1/2 SM", -0.5, StatuteMiles, true},
+		{"zero denominator", "1/0SM", 0, DistanceUnit{}, true},
+		{"bad fraction", "1/2/3SM", 0, DistanceUnit{}, true},
+		{"too many fields", "1 1 1/2SM", 0, DistanceUnit{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDistance(tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got.Unit() != tc.unit {
+				t.Errorf("expected unit %v, got %v", tc.unit, got.Unit())
+			}
+
+			if !tests.CloseEnough(got.measurement, tc.want, tests.Tolerance) {
+				t.Errorf("expected %v, got %v", tc.want, got.measurement)
+			}
+		})
+	}
+}
+
+func TestParsePressure(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		s    string
+		want Pressure
+		err  bool
+	}{
+		{"inches of mercury", "29.92 inHg", NewPressure(29.92, InHg), false},
+		{"hectopascals", "1013hPa", NewPressure(1013, HPa), false},
+		{"millibars", "1013.25 mb", NewPressure(1013.25, Mb), false},
+		{"millibars alias", "1013 mbar", NewPressure(1013, Mb), false},
+		{"upper case", "29.92 INHG", NewPressure(29.92, InHg), false},
+		{"torr", "760 mmHg", NewPressure(760, Torr), false},
+		{"kilopascals", "101.325 kPa", NewPressure(101.325, KPa), false},
+		{"unknown unit", "1013 atm", Pressure{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePressure(tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseVelocity(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		s    string
+		want Velocity
+		err  bool
+	}{
+		{"knots", "12 kts", NewVelocity(12, Kts), false},
+		{"knots alias", "12 kt", NewVelocity(12, Kts), false},
+		{"kilometers per hour", "30 km/h", NewVelocity(30, Kph), false},
+		{"feet per second", "10 ft/s", NewVelocity(10, Fps), false},
+		{"upper case", "5 MPS", NewVelocity(5, Mps), false},
+		{"unknown unit", "12 mach", Velocity{}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseVelocity(tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
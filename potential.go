@@ -0,0 +1,291 @@
+package wx
+
+import "math"
+
+const (
+	// kappaDry is the ratio of the gas constant to the specific heat
+	// at constant pressure of dry air used by Bolton (1980).
+	kappaDry = 0.2854
+	// referencePressure is the reference pressure in hPa of
+	// potential temperatures.
+	referencePressure = 1000.0
+	// maxMoistAdiabatK is the upper bound in kelvin when solving for
+	// the temperature on a pseudo-adiabat.
+	maxMoistAdiabatK = 75 - absoluteZeroC
+)
+
+// PotentialTemp returns the potential temperature θ of dry air at a
+// temperature and pressure, which is the temperature the air would
+// have if brought dry adiabatically to 1000 hPa. The result is in the
+// same unit as t and is invalid if either input is invalid or the
+// pressure is zero.
+func PotentialTemp(t Temp, p Pressure) Temp {
+	if !t.valid || !p.valid || p.measurement == 0 {
+		return Temp{}
+	}
+
+	theta := func(x []float64) float64 {
+		return potentialTempK(x[0], x[1])
+	}
+
+	k, hPa := t.ToK(), p.ToHPa()
+	x := []float64{k.measurement, hPa.measurement}
+	sigma := []float64{k.uncertainty, hPa.uncertainty}
+
+	return NewTemp(theta(x), Kelvin).WithUncertainty(propagate(theta, x, sigma)).To(t.unit)
+}
+
+// DryAdiabat returns the temperature at a pressure on the dry
+// adiabat with the potential temperature theta, which is the
+// temperature of dry air with that potential temperature at the
+// pressure. The result is in the same unit as theta and is invalid if
+// either input is invalid.
+func DryAdiabat(theta Temp, p Pressure) Temp {
+	if !theta.valid || !p.valid {
+		return Temp{}
+	}
+
+	t := func(x []float64) float64 {
+		return dryAdiabatK(x[0], x[1])
+	}
+
+	k, hPa := theta.ToK(), p.ToHPa()
+	x := []float64{k.measurement, hPa.measurement}
+	sigma := []float64{k.uncertainty, hPa.uncertainty}
+
+	return NewTemp(t(x), Kelvin).WithUncertainty(propagate(t, x, sigma)).To(theta.unit)
+}
+
+// MoistAdiabat returns the temperature at a pressure on the
+// pseudo-adiabat with the equivalent potential temperature thetaE,
+// which is the temperature of saturated air with that equivalent
+// potential temperature at the pressure. Air too cold to hold any
+// meaningful moisture follows the dry adiabat.
+//
+// An error is returned if either input is invalid, the pressure is
+// zero or saturated air at the pressure cannot reach the equivalent
+// potential temperature. The result is in the same unit as thetaE.
+func MoistAdiabat(thetaE Temp, p Pressure, f Formulation) (Temp, error) {
+	if !thetaE.valid || !p.valid || f.formulationType == 0 {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature, pressure or formulation", "moist adiabat")
+	}
+
+	k, hPa := thetaE.ToK(), p.ToHPa()
+	if hPa.measurement == 0 {
+		return Temp{}, NewWxErrKind(ErrOutOfDomain, "zero pressure", "moist adiabat")
+	}
+
+	if _, ok := moistAdiabatK(k.measurement, hPa.measurement, f); !ok {
+		return Temp{}, NewWxErrKind(ErrOutOfDomain, "equivalent potential temperature too high for the pressure", "moist adiabat")
+	}
+
+	t := func(x []float64) float64 {
+		t, _ := moistAdiabatK(x[0], x[1], f)
+		return t
+	}
+
+	x := []float64{k.measurement, hPa.measurement}
+	sigma := []float64{k.uncertainty, hPa.uncertainty}
+
+	return NewTemp(t(x), Kelvin).WithUncertainty(propagate(t, x, sigma)).To(thetaE.unit), nil
+}
+
+// LCL returns the temperature and pressure of the lifting
+// condensation level of air at a temperature, dew point and pressure
+// using equation 15 of Bolton (1980). It is where the air becomes
+// saturated when lifted dry adiabatically.
+//
+// An error is returned if any input is invalid or the dew point is
+// above the temperature. The temperature is in the same unit as t and
+// the pressure in the same unit as p.
+func LCL(t, dewPoint Temp, p Pressure) (Temp, Pressure, error) {
+	if !t.valid || !dewPoint.valid || !p.valid {
+		return Temp{}, Pressure{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature, dew point or pressure", "lifting condensation level")
+	}
+
+	k, dk, hPa := t.ToK(), dewPoint.ToK(), p.ToHPa()
+	if dk.measurement > k.measurement {
+		return Temp{}, Pressure{}, NewWxErrKind(ErrOutOfDomain, "dew point above temperature", "lifting condensation level")
+	}
+
+	tl := func(x []float64) float64 {
+		return lclTempK(x[0], x[1])
+	}
+	pl := func(x []float64) float64 {
+		return x[2] * math.Pow(tl(x)/x[0], 1/kappaDry)
+	}
+
+	x := []float64{k.measurement, dk.measurement, hPa.measurement}
+	sigma := []float64{k.uncertainty, dk.uncertainty, hPa.uncertainty}
+
+	return NewTemp(tl(x), Kelvin).WithUncertainty(propagate(tl, x, sigma)).To(t.unit),
+		NewPressure(pl(x), HPa).WithUncertainty(propagate(pl, x, sigma)).To(p.unit), nil
+}
+
+// VirtualTemp returns the virtual temperature of moist air at a
+// temperature, dew point and pressure, which is the temperature dry
+// air would need to have the same density.
+//
+// An error is returned if any input is invalid or the vapor pressure
+// is not below the pressure. The result is in the same unit as t.
+func VirtualTemp(t, dewPoint Temp, p Pressure, f Formulation) (Temp, error) {
+	return moistTemp(t, dewPoint, p, f, "virtual temperature", func(tk, tdk, hPa float64) float64 {
+		return virtualTempK(tk, mixingRatio(tdk, hPa, f))
+	})
+}
+
+// VirtualPotentialTemp returns the virtual potential temperature θv
+// of moist air at a temperature, dew point and pressure, which is the
+// potential temperature of its virtual temperature.
+//
+// An error is returned if any input is invalid or the vapor pressure
+// is not below the pressure. The result is in the same unit as t.
+func VirtualPotentialTemp(t, dewPoint Temp, p Pressure, f Formulation) (Temp, error) {
+	return moistTemp(t, dewPoint, p, f, "virtual potential temperature", func(tk, tdk, hPa float64) float64 {
+		return potentialTempK(virtualTempK(tk, mixingRatio(tdk, hPa, f)), hPa)
+	})
+}
+
+// EquivalentPotentialTemp returns the equivalent potential
+// temperature θe of moist air at a temperature, dew point and
+// pressure using equation 43 of Bolton (1980). It is the potential
+// temperature the air would have if all of its moisture condensed
+// and the latent heat warmed it, and is conserved by both dry and
+// pseudo-adiabatic processes.
+//
+// An error is returned if any input is invalid or the vapor pressure
+// is not below the pressure. The result is in the same unit as t.
+func EquivalentPotentialTemp(t, dewPoint Temp, p Pressure, f Formulation) (Temp, error) {
+	return moistTemp(t, dewPoint, p, f, "equivalent potential temperature", func(tk, tdk, hPa float64) float64 {
+		return equivalentPotentialTempK(tk, tdk, hPa, f)
+	})
+}
+
+// WetBulbPotentialTemp returns the wet-bulb potential temperature θw
+// of moist air at a temperature, dew point and pressure. It is the
+// temperature of saturated air at 1000 hPa with the same equivalent
+// potential temperature, which is where the pseudo-adiabat through
+// the air's lifting condensation level reaches 1000 hPa.
+//
+// An error is returned if any input is invalid or the vapor pressure
+// is not below the pressure. The result is in the same unit as t.
+func WetBulbPotentialTemp(t, dewPoint Temp, p Pressure, f Formulation) (Temp, error) {
+	return moistTemp(t, dewPoint, p, f, "wet-bulb potential temperature", func(tk, tdk, hPa float64) float64 {
+		return wetBulbPotentialTempK(equivalentPotentialTempK(tk, tdk, hPa, f), f)
+	})
+}
+
+// moistTemp validates the inputs of a temperature of moist air and
+// returns the temperature in kelvin that fn returns for the
+// temperature and dew point in kelvin and the pressure in hPa,
+// propagating the uncertainty of the inputs.
+func moistTemp(t, dewPoint Temp, p Pressure, f Formulation, context string, fn func(tk, tdk, hPa float64) float64) (Temp, error) {
+	if !t.valid {
+		return Temp{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature", context)
+	}
+
+	if _, _, err := moisture(dewPoint, p, f, context); err != nil {
+		return Temp{}, err
+	}
+
+	g := func(x []float64) float64 {
+		return fn(x[0], x[1], x[2])
+	}
+
+	k, dk, hPa := t.ToK(), dewPoint.ToK(), p.ToHPa()
+	x := []float64{k.measurement, dk.measurement, hPa.measurement}
+	sigma := []float64{k.uncertainty, dk.uncertainty, hPa.uncertainty}
+
+	return NewTemp(g(x), Kelvin).WithUncertainty(propagate(g, x, sigma)).To(t.unit), nil
+}
+
+// potentialTempK returns the potential temperature in kelvin for a
+// temperature in kelvin and pressure in hPa.
+func potentialTempK(tk, hPa float64) float64 {
+	return tk * math.Pow(referencePressure/hPa, kappaDry)
+}
+
+// dryAdiabatK returns the temperature in kelvin on the dry adiabat
+// with the potential temperature theta in kelvin at a pressure in hPa.
+func dryAdiabatK(theta, hPa float64) float64 {
+	return theta * math.Pow(hPa/referencePressure, kappaDry)
+}
+
+// virtualTempK returns the virtual temperature in kelvin for a
+// temperature in kelvin and mixing ratio in kg/kg.
+func virtualTempK(tk, w float64) float64 {
+	return tk * (1 + w/epsilonMoist) / (1 + w)
+}
+
+// mixingRatio returns the mixing ratio in kg/kg for a dew point in
+// kelvin and pressure in hPa.
+func mixingRatio(tdk, hPa float64, f Formulation) float64 {
+	e := saturationVaporPressure(tdk+absoluteZeroC, f, false)
+	return epsilonMoist * e / (hPa - e)
+}
+
+// equivalentPotentialTempK returns the equivalent potential
+// temperature in kelvin of Bolton (1980) for a temperature and dew
+// point in kelvin and pressure in hPa.
+func equivalentPotentialTempK(tk, tdk, hPa float64, f Formulation) float64 {
+	r := mixingRatio(tdk, hPa, f) * 1000
+	tl := lclTempK(tk, tdk)
+
+	return tk * math.Pow(referencePressure/hPa, kappaDry*(1-0.28e-3*r)) *
+		math.Exp((3.376/tl-0.00254)*r*(1+0.81e-3*r))
+}
+
+// lclTempK returns the temperature in kelvin at the lifting
+// condensation level of air at a temperature and dew point in kelvin
+// using equation 15 of Bolton (1980).
+func lclTempK(tk, tdk float64) float64 {
+	return 1/(1/(tdk-56)+math.Log(tk/tdk)/800) + 56
+}
+
+// wetBulbPotentialTempK returns the temperature in kelvin of
+// saturated air at 1000 hPa with the given equivalent potential
+// temperature.
+func wetBulbPotentialTempK(thetaE float64, f Formulation) float64 {
+	t, _ := moistAdiabatK(thetaE, referencePressure, f)
+	return t
+}
+
+// moistAdiabatK returns the temperature in kelvin of saturated air at
+// a pressure in hPa with the given equivalent potential temperature,
+// and false if saturated air at the pressure is too cold to reach it.
+// The equivalent potential temperature of saturated air increases
+// with its temperature, so it is found by bisection. Below the lowest
+// dew point the air holds no meaningful moisture and follows the dry
+// adiabat.
+func moistAdiabatK(thetaE, hPa float64, f Formulation) (float64, bool) {
+	// saturated returns the equivalent potential temperature of
+	// saturated air, which is unbounded once the vapor pressure
+	// reaches the pressure.
+	saturated := func(tk float64) float64 {
+		if saturationVaporPressure(tk+absoluteZeroC, f, false) >= hPa {
+			return math.Inf(1)
+		}
+
+		return equivalentPotentialTempK(tk, tk, hPa, f)
+	}
+
+	lo, hi := minDewPointC-absoluteZeroC, maxMoistAdiabatK
+	if thetaE < saturated(lo) {
+		return dryAdiabatK(thetaE, hPa), true
+	}
+
+	if thetaE > saturated(hi) {
+		return hi, false
+	}
+
+	for i := 0; i < 100 && hi-lo > 1e-10; i++ {
+		mid := (lo + hi) / 2
+		if saturated(mid) < thetaE {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2, true
+}
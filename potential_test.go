@@ -0,0 +1,235 @@
+package wx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestPotentialTemp(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		got   Temp
+		want  float64
+		unit  TempUnit
+		valid bool
+	}{
+		{"850 hPa", PotentialTemp(NewTemp(293.15, Kelvin), NewPressure(850, HPa)), 307.07, Kelvin, true},
+		{"reference pressure", PotentialTemp(NewTemp(15, Celsius), NewPressure(1000, HPa)), 15, Celsius, true},
+		{"500 hPa", PotentialTemp(NewTemp(-20, Celsius), NewPressure(500, HPa)), 35.38, Celsius, true},
+		{"in fahrenheit", PotentialTemp(NewTemp(68, Fahrenheit), NewPressure(25.10, InHg)), 93.05, Fahrenheit, true},
+		{"invalid temperature", PotentialTemp(Temp{}, NewPressure(850, HPa)), 0, Celsius, false},
+		{"zero pressure", PotentialTemp(NewTemp(20, Celsius), NewPressure(0, HPa)), 0, Celsius, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, tc.got.Valid())
+			}
+
+			if tc.valid && (tc.got.Unit() != tc.unit || !tests.CloseEnough(tc.got.measurement, tc.want, 0.01)) {
+				t.Errorf("expected %v %v, got %v", tc.want, tc.unit, tc.got)
+			}
+		})
+	}
+
+	// The uncertainty of the temperature is scaled.
+	got := PotentialTemp(NewTemp(20, Celsius).WithUncertainty(0.5), NewPressure(850, HPa))
+	if !tests.CloseEnough(got.Uncertainty(), 0.5*307.07/293.15, 1e-4) {
+		t.Errorf("expected ± %v, got %v", 0.5*307.07/293.15, got.Uncertainty())
+	}
+}
+
+func TestMoistPotentialTemps(t *testing.T) {
+	t.Parallel()
+
+	temp, dp, p := NewTemp(20, Celsius), NewTemp(15, Celsius), NewPressure(850, HPa)
+
+	tt := []struct {
+		name string
+		f    func(t, dewPoint Temp, p Pressure, f Formulation) (Temp, error)
+		want float64
+		// potential is true for potential temperatures that moisture
+		// raises above the dry potential temperature.
+		potential bool
+	}{
+		{"virtual temperature", VirtualTemp, 295.39, false},
+		{"virtual potential temperature", VirtualPotentialTemp, 309.41, true},
+		{"equivalent potential temperature", EquivalentPotentialTemp, 345.62, true},
+		{"wet-bulb potential temperature", WetBulbPotentialTemp, 295.61, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f(temp, dp, p, Magnus)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Unit() != Celsius || !tests.CloseEnough(got.K(), tc.want, 0.01) {
+				t.Errorf("expected %v K in Celsius, got %v", tc.want, got)
+			}
+
+			if dry := PotentialTemp(temp, p); tc.potential && got.K() <= dry.K() {
+				t.Errorf("expected more than %v, got %v", dry, got)
+			}
+		})
+	}
+
+	// At 1000 hPa the wet-bulb potential temperature is the
+	// pseudo-adiabatic wet-bulb temperature, which is close to the
+	// psychrometric wet-bulb temperature.
+	sea := NewPressure(1000, HPa)
+	thetaW, err := WetBulbPotentialTemp(NewTemp(25, Celsius), NewTemp(20, Celsius), sea, Magnus)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rh := RelativeHumidity(NewTemp(25, Celsius), NewTemp(20, Celsius), Magnus)
+	if wb := WetBulb(NewTemp(25, Celsius), rh, sea, Magnus); !tests.CloseEnough(thetaW.C(), wb.C(), 0.1) {
+		t.Errorf("expected about %v, got %v", wb, thetaW)
+	}
+
+	// The equivalent potential temperature of saturated air is
+	// conserved along its pseudo-adiabat.
+	thetaE, _ := EquivalentPotentialTemp(thetaW, thetaW, sea, Magnus)
+	want, _ := EquivalentPotentialTemp(NewTemp(25, Celsius), NewTemp(20, Celsius), sea, Magnus)
+	if !tests.CloseEnough(thetaE.K(), want.K(), 1e-6) {
+		t.Errorf("expected %v, got %v", want, thetaE)
+	}
+
+	// The uncertainty of the inputs is propagated.
+	if got, _ := EquivalentPotentialTemp(temp, dp.WithUncertainty(0.5), p, Magnus); got.Uncertainty() <= 0 {
+		t.Errorf("expected an uncertainty, got %v", got.Uncertainty())
+	}
+}
+
+func TestMoistPotentialTemps_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"invalid temperature", func() error {
+			_, err := VirtualTemp(Temp{}, NewTemp(10, Celsius), NewPressure(850, HPa), Magnus)
+			return err
+		}(), ErrInvalidMeasurement},
+		{"invalid dew point", func() error {
+			_, err := EquivalentPotentialTemp(NewTemp(10, Celsius), Temp{}, NewPressure(850, HPa), Magnus)
+			return err
+		}(), ErrInvalidMeasurement},
+		{"invalid formulation", func() error {
+			_, err := WetBulbPotentialTemp(NewTemp(10, Celsius), NewTemp(5, Celsius), NewPressure(850, HPa), Formulation{})
+			return err
+		}(), ErrInvalidMeasurement},
+		{"vapor pressure above pressure", func() error {
+			_, err := VirtualPotentialTemp(NewTemp(90, Celsius), NewTemp(90, Celsius), NewPressure(500, HPa), Magnus)
+			return err
+		}(), ErrOutOfDomain},
+		{"invalid moist adiabat", func() error {
+			_, err := MoistAdiabat(NewTemp(300, Kelvin), Pressure{}, Magnus)
+			return err
+		}(), ErrInvalidMeasurement},
+		{"moist adiabat at zero pressure", func() error {
+			_, err := MoistAdiabat(NewTemp(300, Kelvin), NewPressure(0, HPa), Magnus)
+			return err
+		}(), ErrOutOfDomain},
+		{"moist adiabat too warm", func() error {
+			_, err := MoistAdiabat(NewTemp(1e5, Kelvin), NewPressure(1000, HPa), Magnus)
+			return err
+		}(), ErrOutOfDomain},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, tc.err)
+			}
+		})
+	}
+}
+
+func TestAdiabats(t *testing.T) {
+	t.Parallel()
+
+	p := NewPressure(850, HPa)
+
+	// The dry adiabat is the inverse of the potential temperature.
+	if got := DryAdiabat(NewTemp(307.07, Kelvin), p); got.Unit() != Kelvin || !tests.CloseEnough(got.K(), 293.15, 0.01) {
+		t.Errorf("expected 293.15 K, got %v", got)
+	}
+
+	if got := DryAdiabat(NewTemp(300, Kelvin), Pressure{}); got.Valid() {
+		t.Errorf("expected invalid temperature, got %v", got)
+	}
+
+	// Saturated air on its pseudo-adiabat is at its own temperature.
+	thetaE, _ := EquivalentPotentialTemp(NewTemp(15, Celsius), NewTemp(15, Celsius), p, Magnus)
+	got, err := MoistAdiabat(thetaE, p, Magnus)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Unit() != Celsius || !tests.CloseEnough(got.C(), 15, 1e-6) {
+		t.Errorf("expected 15 °C, got %v", got)
+	}
+
+	// At 1000 hPa it is the wet-bulb potential temperature.
+	sea := NewPressure(1000, HPa)
+	thetaE, _ = EquivalentPotentialTemp(NewTemp(25, Celsius), NewTemp(20, Celsius), sea, Magnus)
+	thetaW, _ := WetBulbPotentialTemp(NewTemp(25, Celsius), NewTemp(20, Celsius), sea, Magnus)
+	if got, _ := MoistAdiabat(thetaE, sea, Magnus); !tests.CloseEnough(got.K(), thetaW.K(), 1e-6) {
+		t.Errorf("expected %v, got %v", thetaW, got)
+	}
+
+	// Air too cold to hold moisture follows the dry adiabat.
+	cold := NewPressure(10, HPa)
+	if got, _ := MoistAdiabat(NewTemp(330, Kelvin), cold, Magnus); !tests.CloseEnough(got.K(), DryAdiabat(NewTemp(330, Kelvin), cold).K(), 1e-9) {
+		t.Errorf("expected the dry adiabat, got %v", got)
+	}
+
+	if got, _ := MoistAdiabat(thetaE.WithUncertainty(1), p, Magnus); got.Uncertainty() <= 0 {
+		t.Errorf("expected an uncertainty, got %v", got.Uncertainty())
+	}
+}
+
+func TestLCL(t *testing.T) {
+	t.Parallel()
+
+	temp, dp, p := NewTemp(20, Celsius), NewTemp(15, Celsius), NewPressure(1000, HPa)
+
+	tl, pl, err := LCL(temp, dp, p)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if tl.Unit() != Celsius || pl.Unit() != HPa || tl.C() >= dp.C() || pl.HPa() >= p.HPa() {
+		t.Fatalf("expected an LCL below the dew point and pressure, got %v at %v", tl, pl)
+	}
+
+	// The air is saturated with its original mixing ratio at the LCL.
+	w, _ := MixingRatio(dp, p, Magnus)
+	ws, _ := SaturationMixingRatio(tl, pl, Magnus)
+	if !tests.CloseEnough(ws, w, 1e-5) {
+		t.Errorf("expected a mixing ratio of %v, got %v", w, ws)
+	}
+
+	// Saturated air is at its LCL.
+	if tl, pl, _ := LCL(temp, temp, p.ToInHg()); !tests.CloseEnough(tl.C(), 20, 1e-9) || pl.Unit() != InHg || !tests.CloseEnough(pl.HPa(), 1000, 1e-9) {
+		t.Errorf("expected 20 °C at 1000 hPa, got %v at %v", tl, pl)
+	}
+
+	if _, _, err := LCL(temp, NewTemp(25, Celsius), p); !errors.Is(err, ErrOutOfDomain) {
+		t.Errorf("expected %v, got %v", ErrOutOfDomain, err)
+	}
+
+	if _, _, err := LCL(temp, dp, Pressure{}); !errors.Is(err, ErrInvalidMeasurement) {
+		t.Errorf("expected %v, got %v", ErrInvalidMeasurement, err)
+	}
+}
@@ -1,6 +1,9 @@
 package wx
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // pressureType represents a unit of pressure.
 type pressureType uint8
@@ -25,25 +28,12 @@ const (
 	mb                           // millibars
 	pa                           // pascals
 	psi                          // pounds per square inch
+	torr                         // torr
 )
 
 // String returns the string representation of the pressure unit.
 func (p pressureType) String() string {
-	switch p {
-	case hPa:
-		return "hPa"
-	case inHg:
-		return "inHg"
-	case kPa:
-		return "kPa"
-	case mb:
-		return "mb"
-	case pa:
-		return "Pa"
-	case psi:
-		return "psi"
-	}
-	return ""
+	return pressureUnits[p].symbol
 }
 
 // PressureUnit is a type for pressure units.
@@ -56,6 +46,12 @@ func (u PressureUnit) String() string {
 	return u.pressureType.String()
 }
 
+// definition returns the registry entry of the pressure unit.
+func (u PressureUnit) definition() (unitDefinition, bool) {
+	def, ok := pressureUnits[u.pressureType]
+	return def, ok
+}
+
 // Pressure units.
 var (
 	// HPa is hectopascals.
@@ -75,6 +71,9 @@ var (
 
 	// Psi is pounds per square inch.
 	Psi = PressureUnit{psi}
+
+	// Torr is torr (1/760 of a standard atmosphere).
+	Torr = PressureUnit{torr}
 )
 
 const (
@@ -89,13 +88,29 @@ const (
 
 	// convertPascalsToInHg is the conversion factor for pascals to inches of mercury.
 	convertPascalsToInHg = 1 / convertInHgToPascals
+
+	// convertTorrToPascals is the conversion factor for torr to pascals.
+	convertTorrToPascals = 101_325.0 / 760.0
 )
 
+// pressureUnits is the registry of pressure units. The base unit is
+// the pascal.
+var pressureUnits = map[pressureType]unitDefinition{
+	hPa:  {symbol: "hPa", scale: 100},
+	inHg: {symbol: "inHg", scale: convertInHgToPascals},
+	kPa:  {symbol: "kPa", scale: 1000},
+	mb:   {symbol: "mb", scale: 100},
+	pa:   {symbol: "Pa", scale: 1},
+	psi:  {symbol: "psi", scale: convertPsiToPascals},
+	torr: {symbol: "Torr", scale: convertTorrToPascals},
+}
+
 // Pressure represents a pressure measurement.
-type Pressure struct {
-	measurement float64
-	unit        PressureUnit
-	valid       bool
+type Pressure Quantity[PressureUnit]
+
+// Quantity returns the pressure as a generic quantity.
+func (p Pressure) Quantity() Quantity[PressureUnit] {
+	return Quantity[PressureUnit](p)
 }
 
 // String returns the string representation of the pressure.
@@ -112,188 +127,237 @@ func (p Pressure) Valid() bool {
 	return p.valid
 }
 
+// Unit returns the unit of the pressure.
+func (p Pressure) Unit() PressureUnit {
+	return p.unit
+}
+
 // NewPressure creates a new Pressure value.
 func NewPressure(measurement float64, unit PressureUnit) Pressure {
-	switch unit.pressureType {
-	case hPa:
-		unit = HPa
-	case inHg:
-		unit = InHg
-	case kPa:
-		unit = KPa
-	case mb:
-		unit = Mb
-	case pa:
-		unit = Pa
-	case psi:
-		unit = Psi
-	default:
-		return Pressure{valid: false}
-	}
+	return Pressure(NewQuantity(measurement, unit))
+}
 
-	return Pressure{
-		measurement: measurement,
-		unit:        unit,
-		valid:       measurement >= 0,
-	}
+// NewPressureE creates a new pressure like NewPressure, but returns an error
+// describing why the pressure is invalid, such as a RangeError for a
+// negative pressure.
+func NewPressureE(measurement float64, unit PressureUnit) (Pressure, error) {
+	return NewPressure(measurement, unit), checkQuantity(measurement, unit, "pressure")
 }
 
 // HPa returns the pressure in hectopascals.
 func (p Pressure) HPa() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement
-	case inHg:
-		return p.measurement * convertInHgToPascals / 100.0
-	case kPa:
-		return p.measurement * 10.0
-	case mb:
-		return p.measurement
-	case pa:
-		return p.measurement / 100.0
-	case psi:
-		return p.measurement * convertPsiToPascals / 100.0
-	}
-
-	return 0
+	return p.In(HPa)
 }
 
 // InHg returns the pressure in inches of mercury.
 func (p Pressure) InHg() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement * 100 * convertPascalsToInHg
-	case inHg:
-		return p.measurement
-	case kPa:
-		return p.measurement * 1000.0 * convertPascalsToInHg
-	case mb:
-		return p.measurement / convertInHgToPascals * 100.0
-	case pa:
-		return p.measurement / convertInHgToPascals
-	case psi:
-		return p.measurement * convertPsiToPascals * convertPascalsToInHg
-	}
-
-	return 0
+	return p.In(InHg)
 }
 
 // KPa returns the pressure in kilopascals.
 func (p Pressure) KPa() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement / 10.0
-	case inHg:
-		return p.measurement * convertInHgToPascals / 1000
-	case kPa:
-		return p.measurement
-	case mb:
-		return p.measurement / 10.0
-	case pa:
-		return p.measurement / 1000.0
-	case psi:
-		return p.measurement * convertPsiToPascals / 1000
-	}
-
-	return 0
+	return p.In(KPa)
 }
 
 // Mb returns the pressure in millibars.
 func (p Pressure) Mb() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement
-	case inHg:
-		return p.measurement * convertInHgToPascals / 100.0
-	case kPa:
-		return p.measurement * 10.0
-	case mb:
-		return p.measurement
-	case pa:
-		return p.measurement / 100.0
-	case psi:
-		return p.measurement * convertPsiToPascals / 100.0
-	}
-
-	return 0
+	return p.In(Mb)
 }
 
 // Pa returns the pressure in pascals.
 func (p Pressure) Pa() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement * 100.0
-	case inHg:
-		return p.measurement * convertInHgToPascals
-	case kPa:
-		return p.measurement * 1000.0
-	case mb:
-		return p.measurement * 100.0
-	case pa:
-		return p.measurement
-	case psi:
-		return p.measurement * convertPsiToPascals
-	}
-
-	return 0
+	return p.In(Pa)
 }
 
 // Psi returns the pressure in pounds per square inch.
 func (p Pressure) Psi() float64 {
-	switch p.unit.pressureType {
-	case hPa:
-		return p.measurement * 100.0 / convertPsiToPascals
-	case inHg:
-		return p.measurement * convertInHgToPascals / convertPsiToPascals
-	case kPa:
-		return p.measurement * 1000.0 / convertPsiToPascals
-	case mb:
-		return p.measurement * 100.0 / convertPsiToPascals
-	case pa:
-		return p.measurement / convertPsiToPascals
-	case psi:
-		return p.measurement
-	}
-
-	return 0
+	return p.In(Psi)
 }
 
 // ToHPa returns the pressure in hectopascals.
 func (p Pressure) ToHPa() Pressure {
-	return NewPressure(p.HPa(), HPa)
+	return p.To(HPa)
 }
 
 // ToInHg returns the pressure in inches of mercury.
 func (p Pressure) ToInHg() Pressure {
-	return NewPressure(p.InHg(), InHg)
+	return p.To(InHg)
 }
 
 // ToKPa returns the pressure in kilopascals.
 func (p Pressure) ToKPa() Pressure {
-	return NewPressure(p.KPa(), KPa)
+	return p.To(KPa)
 }
 
 // ToMb returns the pressure in millibars.
 func (p Pressure) ToMb() Pressure {
-	return NewPressure(p.Mb(), Mb)
+	return p.To(Mb)
 }
 
 // ToPa returns the pressure in pascals.
 func (p Pressure) ToPa() Pressure {
-	return NewPressure(p.Pa(), Pa)
+	return p.To(Pa)
 }
 
 // ToPsi returns the pressure in pounds per square inch.
 func (p Pressure) ToPsi() Pressure {
-	return NewPressure(p.Psi(), Psi)
+	return p.To(Psi)
 }
 
 // Add adds two pressures together and returns a new pressure.
 func (p Pressure) Add(p2 Pressure) Pressure {
-	return NewPressure(p.HPa()+p2.HPa(), HPa)
+	a, b := p.To(HPa), p2.To(HPa)
+	return NewPressure(a.measurement+b.measurement, HPa).
+		WithUncertainty(addUncertainties(a.uncertainty, b.uncertainty))
 }
 
 // Sub subtracts two pressures and returns a new pressure.
 func (p Pressure) Sub(p2 Pressure) Pressure {
-	return NewPressure(p.HPa()-p2.HPa(), HPa)
+	a, b := p.To(HPa), p2.To(HPa)
+	return NewPressure(a.measurement-b.measurement, HPa).
+		WithUncertainty(addUncertainties(a.uncertainty, b.uncertainty))
+}
+
+// In returns the pressure in the specified unit.
+func (p Pressure) In(unit PressureUnit) float64 {
+	return p.Quantity().In(unit)
+}
+
+// To converts the pressure to the specified unit.
+func (p Pressure) To(unit PressureUnit) Pressure {
+	return Pressure(p.Quantity().To(unit))
+}
+
+const (
+	// standardPressureHPa is the ICAO standard sea level pressure.
+	standardPressureHPa = 1013.25
+
+	// standardTempK is the ICAO standard sea level temperature.
+	standardTempK = 288.15
+
+	// standardLapseRate is the ICAO standard temperature lapse rate
+	// in the troposphere in kelvin per meter.
+	standardLapseRate = 0.0065
+
+	// gravity is the standard acceleration of gravity in m/s².
+	gravity = 9.80665
+
+	// gasConstantDryAir is the specific gas constant for dry air
+	// in J/(kg·K).
+	gasConstantDryAir = 287.05
+
+	// altimeterExponent is the exponent of the altimeter setting
+	// equation (R·a/g).
+	altimeterExponent = 0.190284
+
+	// altimeterOffsetHPa is the difference between the station
+	// pressure and the pressure at the altimeter in hPa.
+	altimeterOffsetHPa = 0.3
+)
+
+// AltimeterSetting returns the altimeter setting (QNH) for a
+// station pressure and station elevation using the NWS formula,
+// which reduces the station pressure to sea level through the
+// standard atmosphere.
+//
+// The result is in the same unit as the station pressure and is
+// invalid if either input is invalid.
+func AltimeterSetting(station Pressure, elevation Altitude) Pressure {
+	if !station.valid || !elevation.valid {
+		return Pressure{valid: false}
+	}
+
+	ps := station.HPa() - altimeterOffsetHPa
+	k := math.Pow(standardPressureHPa, altimeterExponent) * standardLapseRate / standardTempK
+	alt := ps * math.Pow(1+k*elevation.M()/math.Pow(ps, altimeterExponent), 1/altimeterExponent)
+
+	return NewPressure(alt, HPa).To(station.unit)
+}
+
+// StationPressure returns the station pressure for an altimeter
+// setting (QNH) and station elevation. It is the inverse of
+// AltimeterSetting and gives the QFE at the elevation.
+//
+// The result is in the same unit as the altimeter setting and is
+// invalid if either input is invalid.
+func StationPressure(altimeter Pressure, elevation Altitude) Pressure {
+	if !altimeter.valid || !elevation.valid {
+		return Pressure{valid: false}
+	}
+
+	k := math.Pow(standardPressureHPa, altimeterExponent) * standardLapseRate / standardTempK
+	ps := math.Pow(math.Pow(altimeter.HPa(), altimeterExponent)-k*elevation.M(), 1/altimeterExponent)
+
+	return NewPressure(ps+altimeterOffsetHPa, HPa).To(altimeter.unit)
+}
+
+// QFE returns the pressure at the aerodrome reference elevation for
+// a pressure measured by a barometer at the given height above it.
+// The air between the barometer and the reference elevation is
+// assumed to be at temperature t.
+//
+// Barometers below the reference elevation have a negative height.
+// The result is in the same unit as the station pressure and is
+// invalid if any input is invalid.
+func QFE(station Pressure, barometerHeight Altitude, t Temp) Pressure {
+	if !station.valid || !t.valid || !barometerHeight.valid {
+		return Pressure{valid: false}
+	}
+
+	qfe := station.HPa() * math.Exp(gravity*barometerHeight.M()/(gasConstantDryAir*t.K()))
+
+	return NewPressure(qfe, HPa).To(station.unit)
+}
+
+// SeaLevelPressure returns the mean sea level pressure (QFF) for a
+// station pressure, station elevation and station temperature. The
+// station pressure is reduced through a fictitious air column whose
+// mean temperature is derived from the station temperature and the
+// standard lapse rate.
+//
+// The result is in the same unit as the station pressure and is
+// invalid if any input is invalid.
+func SeaLevelPressure(station Pressure, elevation Altitude, t Temp) Pressure {
+	if !station.valid || !t.valid || !elevation.valid {
+		return Pressure{valid: false}
+	}
+
+	return reducePressure(station, elevation, t, 1)
+}
+
+// StationPressureFromSeaLevel returns the station pressure for a
+// mean sea level pressure (QFF), station elevation and station
+// temperature. It is the inverse of SeaLevelPressure.
+//
+// The result is in the same unit as the sea level pressure and is
+// invalid if any input is invalid.
+func StationPressureFromSeaLevel(seaLevel Pressure, elevation Altitude, t Temp) Pressure {
+	if !seaLevel.valid || !t.valid || !elevation.valid {
+		return Pressure{valid: false}
+	}
+
+	return reducePressure(seaLevel, elevation, t, -1)
+}
+
+// reducePressure reduces a pressure through the air column between
+// sea level and a station, down to sea level for a sign of 1 or up to
+// the station for a sign of -1. The uncertainties of the pressure,
+// elevation and temperature are propagated to the result.
+func reducePressure(p Pressure, elevation Altitude, t Temp, sign float64) Pressure {
+	reduce := func(x []float64) float64 {
+		return x[0] * math.Exp(sign*gravity*x[1]/(gasConstantDryAir*meanColumnTemp(NewTemp(x[2], Kelvin), x[1])))
+	}
+
+	hPa, m, k := p.ToHPa(), elevation.ToM(), t.ToK()
+	x := []float64{hPa.measurement, m.measurement, k.measurement}
+	sigma := []float64{hPa.uncertainty, m.uncertainty, k.uncertainty}
+
+	return NewPressure(reduce(x), HPa).WithUncertainty(propagate(reduce, x, sigma)).To(p.unit)
+}
+
+// meanColumnTemp returns the mean temperature in kelvin of the air
+// column between sea level and a station at height h meters with
+// temperature t, assuming the standard lapse rate.
+func meanColumnTemp(t Temp, h float64) float64 {
+	return t.K() + standardLapseRate*h/2
 }
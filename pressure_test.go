@@ -184,3 +184,161 @@ func TestPressure_Psi(t *testing.T) {
 		})
 	}
 }
+
+func TestPressure_Unit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		unit PressureUnit
+		want PressureUnit
+	}{
+		{name: "hPa", unit: HPa, want: HPa},
+		{name: "inHg", unit: InHg, want: InHg},
+		{name: "kPa", unit: KPa, want: KPa},
+		{name: "mb", unit: Mb, want: Mb},
+		{name: "pa", unit: Pa, want: Pa},
+		{name: "psi", unit: Psi, want: Psi},
+		{name: "invalid", unit: PressureUnit{}, want: PressureUnit{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPressure(1, tt.unit).Unit(); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAltimeterSetting(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		station   Pressure
+		elevation Altitude
+		want      float64
+		unit      PressureUnit
+		valid     bool
+	}{
+		{name: "standard atmosphere", station: NewPressure(898.75, HPa), elevation: NewAltitude(1000, Meters), want: 1012.9112, unit: HPa, valid: true},
+		{name: "low station", station: NewPressure(1000, HPa), elevation: NewAltitude(100, Meters), want: 1011.6389, unit: HPa, valid: true},
+		{name: "sea level", station: NewPressure(1013.25, HPa), elevation: NewAltitude(0, Meters), want: 1012.95, unit: HPa, valid: true},
+		{name: "inHg", station: NewPressure(898.75, HPa).ToInHg(), elevation: NewAltitude(1000, Meters).ToFt(), want: 1012.9112, unit: InHg, valid: true},
+		{name: "below sea level", station: NewPressure(1020, HPa), elevation: NewAltitude(-400, Meters), want: 972.3222, unit: HPa, valid: true},
+		{name: "invalid", station: NewPressure(-1, HPa), elevation: NewAltitude(0, Meters), valid: false},
+		{name: "invalid elevation", station: NewPressure(898.75, HPa), elevation: Altitude{}, valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AltimeterSetting(tt.station, tt.elevation)
+			if got.Valid() != tt.valid {
+				t.Fatalf("expected valid %v, got %v", tt.valid, got.Valid())
+			}
+
+			if !tt.valid {
+				return
+			}
+
+			if got.Unit() != tt.unit {
+				t.Errorf("expected unit %v, got %v", tt.unit, got.Unit())
+			}
+
+			if math.Abs(got.HPa()-tt.want) > 0.001 {
+				t.Errorf("expected %v, got %v", tt.want, got.HPa())
+			}
+
+			// The station pressure is recovered from the altimeter setting.
+			if back := StationPressure(got, tt.elevation); math.Abs(back.HPa()-tt.station.HPa()) > 1e-9 {
+				t.Errorf("expected station pressure %v, got %v", tt.station.HPa(), back.HPa())
+			}
+		})
+	}
+}
+
+func TestStationPressure(t *testing.T) {
+	t.Parallel()
+
+	// Denver (5,434 ft) with an altimeter setting of 30.12 inHg.
+	got := StationPressure(NewPressure(30.12, InHg), NewAltitude(5434, Feet))
+	if got.Unit() != InHg {
+		t.Errorf("expected unit %v, got %v", InHg, got.Unit())
+	}
+
+	if math.Abs(got.InHg()-24.6726) > 0.001 {
+		t.Errorf("expected 24.6726 inHg, got %v", got.InHg())
+	}
+
+	if StationPressure(Pressure{}, NewAltitude(0, Meters)).Valid() {
+		t.Errorf("expected an invalid pressure")
+	}
+
+	if StationPressure(NewPressure(30.12, InHg), Altitude{}).Valid() {
+		t.Errorf("expected an invalid pressure for an invalid elevation")
+	}
+}
+
+func TestQFE(t *testing.T) {
+	t.Parallel()
+
+	got := QFE(NewPressure(1000, HPa), NewAltitude(10, Meters), NewTemp(15, Celsius))
+	if math.Abs(got.HPa()-1001.1863) > 0.001 {
+		t.Errorf("expected 1001.1863 hPa, got %v", got.HPa())
+	}
+
+	below := QFE(NewPressure(1000, HPa), NewAltitude(-10, Meters), NewTemp(15, Celsius))
+	if below.HPa() >= 1000 {
+		t.Errorf("expected a lower pressure for a barometer below the aerodrome, got %v", below.HPa())
+	}
+
+	if QFE(NewPressure(1000, HPa), NewAltitude(10, Meters), NewTemp(-300, Celsius)).Valid() {
+		t.Errorf("expected an invalid pressure")
+	}
+
+	if QFE(NewPressure(1000, HPa), Altitude{}, NewTemp(15, Celsius)).Valid() {
+		t.Errorf("expected an invalid pressure for an invalid height")
+	}
+}
+
+func TestSeaLevelPressure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		station   Pressure
+		elevation Altitude
+		temp      Temp
+		want      float64
+		valid     bool
+	}{
+		{name: "standard atmosphere", station: NewPressure(898.75, HPa), elevation: NewAltitude(1000, Meters), temp: NewTemp(8.5, Celsius), want: 1013.2509, valid: true},
+		{name: "sea level", station: NewPressure(1013.25, HPa), elevation: NewAltitude(0, Meters), temp: NewTemp(15, Celsius), want: 1013.25, valid: true},
+		{name: "below sea level", station: NewPressure(1013.25, HPa), elevation: NewAltitude(-86, Meters), temp: NewTemp(40, Celsius), want: 1003.7794, valid: true},
+		{name: "invalid temp", station: NewPressure(898.75, HPa), elevation: NewAltitude(1000, Meters), temp: Temp{}, valid: false},
+		{name: "invalid elevation", station: NewPressure(898.75, HPa), elevation: Altitude{}, temp: NewTemp(8.5, Celsius), valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SeaLevelPressure(tt.station, tt.elevation, tt.temp)
+			if got.Valid() != tt.valid {
+				t.Fatalf("expected valid %v, got %v", tt.valid, got.Valid())
+			}
+
+			if !tt.valid {
+				return
+			}
+
+			if math.Abs(got.HPa()-tt.want) > 0.01 {
+				t.Errorf("expected %v, got %v", tt.want, got.HPa())
+			}
+
+			back := StationPressureFromSeaLevel(got, tt.elevation, tt.temp)
+			if math.Abs(back.HPa()-tt.station.HPa()) > 1e-9 {
+				t.Errorf("expected station pressure %v, got %v", tt.station.HPa(), back.HPa())
+			}
+		})
+	}
+}
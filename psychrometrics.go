@@ -0,0 +1,145 @@
+package wx
+
+const (
+	// gasConstantWaterVapor is the specific gas constant for water
+	// vapor in J/(kg·K).
+	gasConstantWaterVapor = 461.5
+	// epsilonMoist is the ratio of the gas constants of dry air and
+	// water vapor.
+	epsilonMoist = 0.622
+)
+
+// SaturationVaporPressure returns the saturation vapor pressure over
+// water at a temperature using the given formulation. The result is
+// in hectopascals and is invalid if the temperature or formulation
+// is invalid.
+func SaturationVaporPressure(t Temp, f Formulation) Pressure {
+	return saturationPressure(t, f, false)
+}
+
+// SaturationVaporPressureIce returns the saturation vapor pressure
+// over ice at a temperature using the given formulation. It is only
+// meaningful below freezing. The result is in hectopascals and is
+// invalid if the temperature or formulation is invalid.
+func SaturationVaporPressureIce(t Temp, f Formulation) Pressure {
+	return saturationPressure(t, f, true)
+}
+
+// VaporPressure returns the actual vapor pressure of the air, which
+// is the saturation vapor pressure over water at the dew point. The
+// result is in hectopascals.
+func VaporPressure(dewPoint Temp, f Formulation) Pressure {
+	return saturationPressure(dewPoint, f, false)
+}
+
+// saturationPressure returns the saturation vapor pressure over water
+// or ice as a pressure, propagating the uncertainty of t.
+func saturationPressure(t Temp, f Formulation, ice bool) Pressure {
+	if !t.valid || f.formulationType == 0 {
+		return Pressure{valid: false}
+	}
+
+	es := func(x []float64) float64 {
+		return saturationVaporPressure(x[0], f, ice)
+	}
+
+	c := t.ToC()
+	x := []float64{c.measurement}
+
+	return NewPressure(es(x), HPa).WithUncertainty(propagate(es, x, []float64{c.uncertainty}))
+}
+
+// MixingRatio returns the mass of water vapor per mass of dry air in
+// kg/kg for a dew point and station pressure.
+//
+// An error is returned if either input is invalid or the vapor
+// pressure is not below the station pressure.
+func MixingRatio(dewPoint Temp, station Pressure, f Formulation) (float64, error) {
+	e, p, err := moisture(dewPoint, station, f, "mixing ratio")
+	if err != nil {
+		return 0, err
+	}
+
+	return epsilonMoist * e / (p - e), nil
+}
+
+// SaturationMixingRatio returns the mixing ratio in kg/kg of air
+// saturated with respect to water at a temperature and station
+// pressure.
+func SaturationMixingRatio(t Temp, station Pressure, f Formulation) (float64, error) {
+	return MixingRatio(t, station, f)
+}
+
+// DewPointFromMixingRatio returns the dew point of air with a mixing
+// ratio in kg/kg at a station pressure, the inverse of MixingRatio.
+// The result is in Celsius and is invalid if the pressure is invalid
+// or the mixing ratio is not positive.
+func DewPointFromMixingRatio(w float64, station Pressure, f Formulation) Temp {
+	if !station.valid || w <= 0 || f.formulationType == 0 {
+		return Temp{}
+	}
+
+	hPa := station.ToHPa()
+	if _, ok := inverseSaturationVaporPressure(vaporPressureFromMixingRatio(w, hPa.measurement), f, false); !ok {
+		return Temp{}
+	}
+
+	td := func(x []float64) float64 {
+		c, _ := inverseSaturationVaporPressure(vaporPressureFromMixingRatio(x[0], x[1]), f, false)
+		return c
+	}
+
+	x := []float64{w, hPa.measurement}
+
+	return NewTemp(td(x), Celsius).WithUncertainty(propagate(td, x, []float64{0, hPa.uncertainty}))
+}
+
+// vaporPressureFromMixingRatio returns the vapor pressure in hPa of
+// air with a mixing ratio in kg/kg at a pressure in hPa.
+func vaporPressureFromMixingRatio(w, hPa float64) float64 {
+	return w * hPa / (epsilonMoist + w)
+}
+
+// SpecificHumidity returns the mass of water vapor per mass of moist
+// air in kg/kg for a dew point and station pressure.
+//
+// An error is returned if either input is invalid or the vapor
+// pressure is not below the station pressure.
+func SpecificHumidity(dewPoint Temp, station Pressure, f Formulation) (float64, error) {
+	e, p, err := moisture(dewPoint, station, f, "specific humidity")
+	if err != nil {
+		return 0, err
+	}
+
+	return epsilonMoist * e / (p - (1-epsilonMoist)*e), nil
+}
+
+// AbsoluteHumidity returns the density of water vapor in the air in
+// kg/m³ for a temperature and dew point.
+//
+// An error is returned if either temperature is invalid.
+func AbsoluteHumidity(t, dewPoint Temp, f Formulation) (float64, error) {
+	if !t.valid || !dewPoint.valid || f.formulationType == 0 {
+		return 0, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature or dew point", "absolute humidity")
+	}
+
+	e := saturationVaporPressure(dewPoint.C(), f, false) * 100
+
+	return e / (gasConstantWaterVapor * t.K()), nil
+}
+
+// moisture returns the vapor pressure at the dew point and the
+// station pressure in hectopascals.
+func moisture(dewPoint Temp, station Pressure, f Formulation, context string) (e, p float64, err error) {
+	if !dewPoint.valid || !station.valid || f.formulationType == 0 {
+		return 0, 0, NewWxErrKind(ErrInvalidMeasurement, "invalid dew point or pressure", context)
+	}
+
+	e = saturationVaporPressure(dewPoint.C(), f, false)
+	p = station.HPa()
+	if e >= p {
+		return 0, 0, NewWxErrKind(ErrOutOfDomain, "vapor pressure exceeds station pressure", context)
+	}
+
+	return e, p, nil
+}
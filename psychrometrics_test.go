@@ -0,0 +1,127 @@
+package wx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestVaporPressures(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		got   Pressure
+		want  float64
+		valid bool
+	}{
+		{"water at 20 °C", SaturationVaporPressure(NewTemp(20, Celsius), HylandWexler), 23.3880, true},
+		{"water at 0 °C", SaturationVaporPressure(NewTemp(32, Fahrenheit), HylandWexler), 6.1121, true},
+		{"water at -10 °C", SaturationVaporPressure(NewTemp(-10, Celsius), HylandWexler), 2.8656, true},
+		{"water at 100 °C", SaturationVaporPressure(NewTemp(373.15, Kelvin), HylandWexler), 1014.1872, true},
+		{"magnus", SaturationVaporPressure(NewTemp(20, Celsius), Magnus), 23.3344, true},
+		{"ice at -10 °C", SaturationVaporPressureIce(NewTemp(-10, Celsius), HylandWexler), 2.5990, true},
+		{"vapor pressure", VaporPressure(NewTemp(20, Celsius), HylandWexler), 23.3880, true},
+		{"invalid temperature", SaturationVaporPressure(Temp{}, HylandWexler), 0, false},
+		{"invalid formulation", SaturationVaporPressure(NewTemp(20, Celsius), Formulation{}), 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, tc.got.Valid())
+			}
+
+			if tc.valid && (tc.got.Unit() != HPa || !tests.CloseEnough(tc.got.HPa(), tc.want, 1e-4)) {
+				t.Errorf("expected %v hPa, got %v", tc.want, tc.got)
+			}
+		})
+	}
+
+	// The uncertainty of the temperature is propagated.
+	es := SaturationVaporPressure(NewTemp(20, Celsius).WithUncertainty(0.1), HylandWexler)
+	if es.Uncertainty() < 0.1 || es.Uncertainty() > 0.2 {
+		t.Errorf("expected about ± 0.14 hPa, got %v", es.Uncertainty())
+	}
+}
+
+func TestMoistureRatios(t *testing.T) {
+	t.Parallel()
+
+	dp := NewTemp(20, Celsius)
+	p := NewPressure(1013.25, HPa)
+
+	tt := []struct {
+		name string
+		f    func() (float64, error)
+		want float64
+	}{
+		{"mixing ratio", func() (float64, error) { return MixingRatio(dp, p, HylandWexler) }, 0.0146964},
+		{"mixing ratio in inHg", func() (float64, error) { return MixingRatio(dp, p.ToInHg(), HylandWexler) }, 0.0146964},
+		{"saturation mixing ratio", func() (float64, error) {
+			return SaturationMixingRatio(NewTemp(10, Celsius), NewPressure(850, HPa), Magnus)
+		}, 0.0091029},
+		{"specific humidity", func() (float64, error) { return SpecificHumidity(dp, p, HylandWexler) }, 0.0144835},
+		{"absolute humidity", func() (float64, error) { return AbsoluteHumidity(NewTemp(30, Celsius), dp, HylandWexler) }, 0.0167172},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(got, tc.want, 1e-7) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDewPointFromMixingRatio(t *testing.T) {
+	t.Parallel()
+
+	p := NewPressure(850, HPa)
+	for _, f := range []Formulation{Magnus, HylandWexler} {
+		w, _ := MixingRatio(NewTemp(10, Celsius), p, f)
+		if got := DewPointFromMixingRatio(w, p.ToInHg(), f); !got.Valid() || !tests.CloseEnough(got.C(), 10, 1e-6) {
+			t.Errorf("expected 10 °C, got %v", got)
+		}
+	}
+
+	if got := DewPointFromMixingRatio(0, p, Magnus); got.Valid() {
+		t.Errorf("expected invalid dew point, got %v", got)
+	}
+
+	if got := DewPointFromMixingRatio(0.01, Pressure{}, Magnus); got.Valid() {
+		t.Errorf("expected invalid dew point, got %v", got)
+	}
+}
+
+func TestMoistureRatios_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"invalid dew point", func() error { _, err := MixingRatio(Temp{}, NewPressure(1000, HPa), Magnus); return err }(), ErrInvalidMeasurement},
+		{"invalid pressure", func() error { _, err := SpecificHumidity(NewTemp(10, Celsius), Pressure{}, Magnus); return err }(), ErrInvalidMeasurement},
+		{"invalid formulation", func() error {
+			_, err := AbsoluteHumidity(NewTemp(10, Celsius), NewTemp(5, Celsius), Formulation{})
+			return err
+		}(), ErrInvalidMeasurement},
+		{"boiling", func() error { _, err := MixingRatio(NewTemp(90, Celsius), NewPressure(500, HPa), Magnus); return err }(), ErrOutOfDomain},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package wx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Dimension is a physical dimension measured in a set of units,
+// such as TempUnit or DistanceUnit. Each dimension has a registry of
+// its units that defines how to convert them to a base unit, so a
+// unit is added to the package with a single registry entry.
+type Dimension interface {
+	comparable
+	String() string
+
+	// definition returns the registry entry of the unit and false
+	// if the unit is not registered.
+	definition() (unitDefinition, bool)
+}
+
+// unitDefinition is a registry entry describing a unit of a
+// dimension relative to the base unit of the dimension.
+//
+// Linear units are converted with base = (value + offset) * scale.
+// Units that are not linear, such as the Beaufort scale, provide
+// their own conversion functions instead.
+type unitDefinition struct {
+	symbol string
+	scale  float64
+	offset float64
+
+	// min is the lowest valid measurement in the unit.
+	min float64
+
+	toBase   func(float64) float64
+	fromBase func(float64) float64
+}
+
+// base converts a measurement in the unit to the base unit.
+func (u unitDefinition) base(measurement float64) float64 {
+	if u.toBase != nil {
+		return u.toBase(measurement)
+	}
+
+	return (measurement + u.offset) * u.scale
+}
+
+// from converts a measurement in the base unit to the unit.
+func (u unitDefinition) from(base float64) float64 {
+	if u.fromBase != nil {
+		return u.fromBase(base)
+	}
+
+	return base/u.scale - u.offset
+}
+
+// slope returns the derivative of the base unit with respect to the
+// unit at a measurement in the unit. A forward difference is used
+// near the lowest valid measurement, where the conversion may not be
+// defined below it.
+func (u unitDefinition) slope(measurement float64) float64 {
+	if u.toBase == nil {
+		return u.scale
+	}
+
+	h := derivativeStep(measurement)
+	lo := measurement - h
+	if lo < u.min {
+		lo = measurement
+	}
+
+	return (u.toBase(measurement+h) - u.toBase(lo)) / (measurement + h - lo)
+}
+
+// Quantity is a measurement in a unit of the dimension D.
+// Temp, Distance, Pressure and Velocity are all quantities and can
+// be converted to one with their Quantity method, which lets
+// generic code work with any of them.
+//
+// A quantity may carry a standard uncertainty (±σ) in its unit,
+// which is converted along with the measurement and propagated by
+// arithmetic and derived calculations to first order.
+type Quantity[D Dimension] struct {
+	measurement float64
+	unit        D
+	valid       bool
+	uncertainty float64
+}
+
+// NewQuantity creates a new quantity. The quantity is invalid if
+// the unit is not registered or the measurement is below the lowest
+// valid measurement of the unit, e.g. a negative distance or a
+// temperature below absolute zero.
+func NewQuantity[D Dimension](measurement float64, unit D) Quantity[D] {
+	def, ok := unit.definition()
+	if !ok {
+		return Quantity[D]{valid: false}
+	}
+
+	return Quantity[D]{
+		measurement: measurement,
+		unit:        unit,
+		valid:       measurement >= def.min,
+	}
+}
+
+// missing returns true if the quantity has no measurement: it has no
+// unit or is invalid without being below the lowest valid measurement
+// of its unit.
+func (q Quantity[D]) missing() bool {
+	def, ok := q.unit.definition()
+	return !ok || (!q.valid && q.measurement >= def.min)
+}
+
+// Measurement returns the measurement in the unit of the quantity.
+func (q Quantity[D]) Measurement() float64 {
+	return q.measurement
+}
+
+// Unit returns the unit of the quantity.
+func (q Quantity[D]) Unit() D {
+	return q.unit
+}
+
+// Valid returns true if the quantity is valid.
+func (q Quantity[D]) Valid() bool {
+	return q.valid
+}
+
+// Uncertainty returns the standard uncertainty of the measurement in
+// the unit of the quantity, or zero if the uncertainty is not known.
+func (q Quantity[D]) Uncertainty() float64 {
+	return q.uncertainty
+}
+
+// WithUncertainty returns the quantity with a standard uncertainty
+// in the unit of the quantity.
+func (q Quantity[D]) WithUncertainty(sigma float64) Quantity[D] {
+	q.uncertainty = math.Abs(sigma)
+	return q
+}
+
+// In returns the measurement converted to the given unit. It
+// returns 0 if either unit is not registered.
+func (q Quantity[D]) In(unit D) float64 {
+	from, ok := q.unit.definition()
+	if !ok {
+		return 0
+	}
+
+	to, ok := unit.definition()
+	if !ok {
+		return 0
+	}
+
+	if q.unit == unit {
+		return q.measurement
+	}
+
+	return to.from(from.base(q.measurement))
+}
+
+// To converts the quantity to the given unit. The result is invalid
+// if the given unit is not registered. The uncertainty is scaled by
+// the derivative of the conversion at the measurement.
+func (q Quantity[D]) To(unit D) Quantity[D] {
+	c := NewQuantity(q.In(unit), unit)
+	if q.uncertainty == 0 {
+		return c
+	}
+
+	from, ok := q.unit.definition()
+	if !ok {
+		return c
+	}
+
+	to, ok := unit.definition()
+	if !ok {
+		return c
+	}
+
+	c.uncertainty = math.Abs(q.uncertainty * from.slope(q.measurement) / to.slope(c.measurement))
+
+	return c
+}
+
+// String returns the string representation of the quantity.
+func (q Quantity[D]) String() string {
+	if !q.valid {
+		return fmt.Sprintf("invalid %s", q.unit)
+	}
+
+	return fmt.Sprintf("%.2f %s", q.measurement, q.unit)
+}
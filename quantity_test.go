@@ -0,0 +1,130 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestNewQuantity(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		q     interface{ Valid() bool }
+		valid bool
+	}{
+		{"distance", NewQuantity(1, Meters), true},
+		{"negative distance", NewQuantity(-1, Meters), false},
+		{"invalid distance unit", NewQuantity(1, DistanceUnit{99}), false},
+		{"temperature below freezing", NewQuantity(-40, Celsius), true},
+		{"absolute zero", NewQuantity(absoluteZeroF, Fahrenheit), true},
+		{"below absolute zero", NewQuantity(-1, Kelvin), false},
+		{"pressure", NewQuantity(760, Torr), true},
+		{"velocity", NewQuantity(3, Beaufort), true},
+		{"negative velocity", NewQuantity(-3, Mpm), false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.q.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, tc.q.Valid())
+			}
+		})
+	}
+}
+
+func TestQuantity_In(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"furlongs to meters", NewQuantity(1, Furlongs).In(Meters), 201.168},
+		{"statute mile to furlongs", NewQuantity(1, StatuteMiles).In(Furlongs), 8},
+		{"torr to hectopascals", NewQuantity(760, Torr).In(HPa), 1013.25},
+		{"inches of mercury to torr", NewQuantity(1, InHg).In(Torr), 25.4},
+		{"meters per minute to meters per second", NewQuantity(60, Mpm).In(Mps), 1},
+		{"knots to meters per minute", NewQuantity(1, Kts).In(Mpm), 30.8667},
+		{"beaufort to meters per second", NewQuantity(12, Beaufort).In(Mps), 34.7519},
+		{"meters per second to beaufort", NewQuantity(10, Mps).In(Beaufort), 5.2303},
+		{"beaufort to knots", NewQuantity(4, Beaufort).In(Kts), 13.0004},
+		{"celsius to fahrenheit", NewQuantity(100, Celsius).In(Fahrenheit), 212},
+		{"rankine to celsius", NewQuantity(491.67, Rankine).In(Celsius), 0},
+		{"same unit", NewQuantity(1.5, Kts).In(Kts), 1.5},
+		{"invalid unit", NewQuantity(1, Kts).In(VelocityUnit{}), 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tests.CloseEnough(tc.got, tc.want, 1e-4) {
+				t.Errorf("expected %v, got %v", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestQuantity_To(t *testing.T) {
+	t.Parallel()
+
+	q := NewQuantity(3, Beaufort).To(Kts)
+	if !q.Valid() || q.Unit() != Kts {
+		t.Fatalf("expected valid %v, got %v", Kts, q)
+	}
+
+	if got := q.To(Beaufort).Measurement(); !tests.CloseEnough(got, 3, tests.Tolerance) {
+		t.Errorf("expected round trip to 3 Bft, got %v", got)
+	}
+
+	if got := NewQuantity(1, Meters).To(DistanceUnit{}); got.Valid() {
+		t.Errorf("expected invalid quantity, got %v", got)
+	}
+}
+
+func TestQuantity_String(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		q    interface{ String() string }
+		want string
+	}{
+		{"furlongs", NewQuantity(2, Furlongs), "2.00 fur"},
+		{"torr", NewQuantity(760, Torr), "760.00 Torr"},
+		{"meters per minute", NewQuantity(12.345, Mpm), "12.35 m/min"},
+		{"beaufort", NewQuantity(5, Beaufort), "5.00 Bft"},
+		{"invalid", NewQuantity(-1, Meters), "invalid m"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.q.String() != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, tc.q.String())
+			}
+		})
+	}
+}
+
+func TestQuantity_Conversions(t *testing.T) {
+	t.Parallel()
+
+	// The measurement types are quantities, so their conversions go
+	// through the same registry.
+	if got := NewDistance(3, Furlongs).Quantity().In(Feet); !tests.CloseEnough(got, 1980, 1e-9) {
+		t.Errorf("expected 1980 ft, got %v", got)
+	}
+
+	if got := NewPressure(29.92, InHg).To(Torr); got.Unit() != Torr || !tests.CloseEnough(got.HPa(), NewPressure(29.92, InHg).HPa(), 1e-9) {
+		t.Errorf("expected 29.92 inHg in Torr, got %v", got)
+	}
+
+	if got := NewVelocity(10, Kts).In(Beaufort); !tests.CloseEnough(got, 3.3581, 1e-4) {
+		t.Errorf("expected 3.3581 Bft, got %v", got)
+	}
+
+	if got := NewTemp(300, Kelvin).To(Celsius); got.Unit() != Celsius || !tests.CloseEnough(got.C(), 26.85, 1e-9) {
+		t.Errorf("expected 26.85 °C, got %v", got)
+	}
+}
@@ -0,0 +1,169 @@
+package wx
+
+import "math"
+
+// sideType represents the side of a runway.
+type sideType uint8
+
+// Runway sides.
+// The values start at 1 so the zero value means no side.
+const (
+	left sideType = iota + 1
+	right
+)
+
+// String returns the string representation of the side.
+func (s sideType) String() string {
+	switch s {
+	case left:
+		return "left"
+	case right:
+		return "right"
+	}
+	return ""
+}
+
+// Side is the side of a runway a crosswind blows from.
+// The zero value means there is no crosswind.
+type Side struct {
+	sideType
+}
+
+// String returns the string representation of the side.
+func (s Side) String() string {
+	return s.sideType.String()
+}
+
+// Runway sides.
+var (
+	// Left is the left side of the runway.
+	Left = Side{left}
+	// Right is the right side of the runway.
+	Right = Side{right}
+)
+
+// WindComponents are the components of a wind speed along and
+// across a runway. All speeds are positive and in the unit of the
+// wind. At most one of Headwind and Tailwind is non-zero.
+type WindComponents struct {
+	Headwind  Velocity
+	Tailwind  Velocity
+	Crosswind Velocity
+	// CrosswindSide is the side of the runway the crosswind blows
+	// from.
+	CrosswindSide Side
+}
+
+// RunwayWind is the wind along and across a runway for the steady
+// wind and, if the wind is gusting, for the gust.
+type RunwayWind struct {
+	Steady WindComponents
+	// Gust holds the components of the gust speed. Its velocities
+	// are invalid if the wind is not gusting.
+	Gust WindComponents
+}
+
+// RunwayEnd is a runway end that aircraft take off from or land
+// towards, e.g. runway 27L with a heading of 274°.
+type RunwayEnd struct {
+	Name    string
+	Heading Degrees
+}
+
+// RunwayWindComponents returns the headwind, tailwind and crosswind
+// for a runway heading and wind. The heading and the wind direction
+// must both be relative to the same north: METAR winds are true,
+// while ATIS winds and runway headings are usually magnetic.
+//
+// An error is returned if the wind is invalid or the direction is
+// variable.
+func RunwayWindComponents(heading Degrees, w Wind) (RunwayWind, error) {
+	if !w.valid {
+		return RunwayWind{}, NewWxErrKind(ErrInvalidMeasurement, "invalid wind", "runway wind")
+	}
+
+	if w.variable {
+		return RunwayWind{}, NewWxErrKind(ErrOutOfDomain, "variable wind direction", "runway wind")
+	}
+
+	rw := RunwayWind{Steady: windComponents(heading, w.direction, w.speed)}
+	if w.Gusting() {
+		rw.Gust = windComponents(heading, w.direction, w.gust)
+	}
+
+	return rw, nil
+}
+
+// BestRunway returns the runway end most aligned with the wind,
+// which is the one with the greatest headwind (or least tailwind),
+// and its wind components. Ties are broken by the least crosswind
+// and then by the order of the runway ends, so with a calm wind the
+// first runway end is returned.
+//
+// An error is returned if there are no runway ends or the wind is
+// invalid or variable.
+func BestRunway(ends []RunwayEnd, w Wind) (RunwayEnd, RunwayWind, error) {
+	if len(ends) == 0 {
+		return RunwayEnd{}, RunwayWind{}, NewWxErrKind(ErrInvalidMeasurement, "no runway ends", "runway wind")
+	}
+
+	var best int
+	var bestWind RunwayWind
+	for i, end := range ends {
+		rw, err := RunwayWindComponents(end.Heading, w)
+		if err != nil {
+			return RunwayEnd{}, RunwayWind{}, err
+		}
+
+		if i == 0 || betterRunwayWind(rw.Steady, bestWind.Steady) {
+			best, bestWind = i, rw
+		}
+	}
+
+	return ends[best], bestWind, nil
+}
+
+// windComponents resolves a wind speed from a direction along and
+// across a runway heading.
+func windComponents(heading Degrees, direction WindDirection, speed Velocity) WindComponents {
+	diff := direction.degrees.Sub(heading).Radians()
+	along := speed.measurement * math.Cos(diff)
+	across := speed.measurement * math.Sin(diff)
+
+	// Treat floating point residue, e.g. from a wind straight
+	// across or down the runway, as zero.
+	if math.Abs(along) < 1e-9 {
+		along = 0
+	}
+
+	if math.Abs(across) < 1e-9 {
+		across = 0
+	}
+
+	c := WindComponents{
+		Headwind:  NewVelocity(math.Max(along, 0), speed.unit),
+		Tailwind:  NewVelocity(math.Max(-along, 0), speed.unit),
+		Crosswind: NewVelocity(math.Abs(across), speed.unit),
+	}
+
+	switch {
+	case across > 0:
+		c.CrosswindSide = Right
+	case across < 0:
+		c.CrosswindSide = Left
+	}
+
+	return c
+}
+
+// betterRunwayWind returns true if a has more headwind than b, or
+// the same headwind and less crosswind.
+func betterRunwayWind(a, b WindComponents) bool {
+	ha := a.Headwind.measurement - a.Tailwind.measurement
+	hb := b.Headwind.measurement - b.Tailwind.measurement
+	if math.Abs(ha-hb) > 1e-9 {
+		return ha > hb
+	}
+
+	return a.Crosswind.measurement < b.Crosswind.measurement-1e-9
+}
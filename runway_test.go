@@ -0,0 +1,169 @@
+package wx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestSide_String(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		side Side
+		want string
+	}{
+		{"left", Left, "left"},
+		{"right", Right, "right"},
+		{"none", Side{}, ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.side.String() != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, tc.side.String())
+			}
+		})
+	}
+}
+
+func TestRunwayWindComponents(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		heading   float64
+		wind      Wind
+		headwind  float64
+		tailwind  float64
+		crosswind float64
+		side      Side
+	}{
+		{"straight down", 270, NewWind(NewWindDirection(270), NewVelocity(15, Kts)), 15, 0, 0, Side{}},
+		{"straight behind", 90, NewWind(NewWindDirection(270), NewVelocity(15, Kts)), 0, 15, 0, Side{}},
+		{"from the right", 360, NewWind(NewWindDirection(90), NewVelocity(10, Kts)), 0, 0, 10, Right},
+		{"from the left", 360, NewWind(NewWindDirection(270), NewVelocity(10, Kts)), 0, 0, 10, Left},
+		{"quartering", 180, NewWind(NewWindDirection(210), NewVelocity(20, Kts)), 17.3205, 0, 10, Right},
+		{"across north", 10, NewWind(NewWindDirection(340), NewVelocity(20, Kts)), 17.3205, 0, 10, Left},
+		{"quartering tailwind", 90, NewWind(NewWindDirection(300), NewVelocity(20, Kts)), 0, 17.3205, 10, Left},
+		{"calm", 90, NewCalmWind(Kts), 0, 0, 0, Side{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			rw, err := RunwayWindComponents(NewDegrees(tc.heading), tc.wind)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			c := rw.Steady
+			if !tests.CloseEnough(c.Headwind.Kts(), tc.headwind, 1e-4) ||
+				!tests.CloseEnough(c.Tailwind.Kts(), tc.tailwind, 1e-4) ||
+				!tests.CloseEnough(c.Crosswind.Kts(), tc.crosswind, 1e-4) {
+				t.Errorf("expected head %v tail %v cross %v, got head %v tail %v cross %v",
+					tc.headwind, tc.tailwind, tc.crosswind, c.Headwind, c.Tailwind, c.Crosswind)
+			}
+
+			if c.CrosswindSide != tc.side {
+				t.Errorf("expected side %q, got %q", tc.side, c.CrosswindSide)
+			}
+
+			if !c.Headwind.Valid() || !c.Tailwind.Valid() || !c.Crosswind.Valid() {
+				t.Errorf("expected valid components, got %+v", c)
+			}
+
+			if rw.Gust.Headwind.Valid() || rw.Gust.Crosswind.Valid() {
+				t.Errorf("expected no gust components, got %+v", rw.Gust)
+			}
+		})
+	}
+}
+
+func TestRunwayWindComponents_Gust(t *testing.T) {
+	t.Parallel()
+
+	w := NewGustingWind(NewWindDirection(240), NewVelocity(10, Mps), NewVelocity(20, Mps))
+
+	rw, err := RunwayWindComponents(NewDegrees(270), w)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if rw.Steady.Headwind.Unit() != Mps || rw.Gust.Headwind.Unit() != Mps {
+		t.Errorf("expected components in %v, got %v and %v", Mps, rw.Steady.Headwind.Unit(), rw.Gust.Headwind.Unit())
+	}
+
+	if !tests.CloseEnough(rw.Gust.Headwind.Mps(), 17.3205, 1e-4) || !tests.CloseEnough(rw.Gust.Crosswind.Mps(), 10, 1e-4) {
+		t.Errorf("expected gust head 17.3205 cross 10, got head %v cross %v", rw.Gust.Headwind, rw.Gust.Crosswind)
+	}
+
+	if rw.Gust.CrosswindSide != Left {
+		t.Errorf("expected gust from %q, got %q", Left, rw.Gust.CrosswindSide)
+	}
+}
+
+func TestRunwayWindComponents_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		wind Wind
+		kind error
+	}{
+		{"invalid", Wind{}, ErrInvalidMeasurement},
+		{"variable", NewVariableWind(NewVelocity(3, Kts)), ErrOutOfDomain},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := RunwayWindComponents(NewDegrees(90), tc.wind); !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}
+
+func TestBestRunway(t *testing.T) {
+	t.Parallel()
+
+	ends := []RunwayEnd{
+		{"09", NewDegrees(90)},
+		{"27", NewDegrees(270)},
+		{"04", NewDegrees(40)},
+		{"22", NewDegrees(220)},
+	}
+
+	tt := []struct {
+		name  string
+		ends  []RunwayEnd
+		wind  Wind
+		want  string
+		isErr bool
+	}{
+		{"westerly", ends, NewWind(NewWindDirection(260), NewVelocity(15, Kts)), "27", false},
+		{"northeasterly", ends, NewWind(NewWindDirection(50), NewVelocity(15, Kts)), "04", false},
+		{"between runways", ends, NewWind(NewWindDirection(240), NewVelocity(15, Kts)), "22", false},
+		{"calm", ends, NewCalmWind(Kts), "09", false},
+		{"variable", ends, NewVariableWind(NewVelocity(3, Kts)), "", true},
+		{"no runways", nil, NewCalmWind(Kts), "", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			end, rw, err := BestRunway(tc.ends, tc.wind)
+			if (err != nil) != tc.isErr {
+				t.Fatalf("expected error %v, got %v", tc.isErr, err)
+			}
+
+			if end.Name != tc.want {
+				t.Errorf("expected runway %q, got %q", tc.want, end.Name)
+			}
+
+			if !tc.isErr && rw.Steady.Tailwind.Kts() > 0 && !tc.wind.Calm() {
+				t.Errorf("expected no tailwind, got %v", rw.Steady.Tailwind)
+			}
+		})
+	}
+}
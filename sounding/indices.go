@@ -0,0 +1,270 @@
+package sounding
+
+import (
+	"math"
+
+	"github.com/go-wx/wx"
+)
+
+// waterDensity is the density of liquid water in kg/m³.
+const waterDensity = 1000.0
+
+// indexLevels returns the interpolated levels at the pressures in hPa of
+// an index.
+func (s Sounding) indexLevels(context string, pressures ...float64) ([]Level, error) {
+	levels := make([]Level, len(pressures))
+	for i, p := range pressures {
+		l, ok := s.at(p)
+		if !ok {
+			return nil, wx.NewWxErrKind(wx.ErrOutOfDomain, "sounding does not span the levels of the index", context)
+		}
+
+		levels[i] = l
+	}
+
+	return levels, nil
+}
+
+// dewPoints returns an error if a level of an index has an invalid
+// dew point.
+func dewPoints(context string, levels ...Level) error {
+	for _, l := range levels {
+		if !l.DewPoint.Valid() {
+			return wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid dew point at "+l.Pressure.String(), context)
+		}
+	}
+
+	return nil
+}
+
+// KIndex returns the K-index of George (1960) in °C:
+//
+//	K = (T850 - T500) + Td850 - (T700 - Td700)
+//
+// Values above 30 indicate a high potential for air mass
+// thunderstorms.
+//
+// An error is returned if the sounding does not span 850 to 500 hPa
+// or the dew point at 850 or 700 hPa is invalid.
+func (s Sounding) KIndex() (float64, error) {
+	l, err := s.indexLevels("k-index", 850, 700, 500)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := dewPoints("k-index", l[0], l[1]); err != nil {
+		return 0, err
+	}
+
+	return l[0].Temp.C() - l[2].Temp.C() + l[0].DewPoint.C() - (l[1].Temp.C() - l[1].DewPoint.C()), nil
+}
+
+// TotalTotals returns the Total Totals index of Miller (1972) in °C:
+//
+//	TT = T850 + Td850 - 2·T500
+//
+// Values above 50 indicate a potential for severe thunderstorms.
+//
+// An error is returned if the sounding does not span 850 to 500 hPa
+// or the dew point at 850 hPa is invalid.
+func (s Sounding) TotalTotals() (float64, error) {
+	l, err := s.indexLevels("total totals", 850, 500)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := dewPoints("total totals", l[0]); err != nil {
+		return 0, err
+	}
+
+	return totalTotals(l[0], l[1]), nil
+}
+
+// totalTotals returns the Total Totals index from the 850 and 500 hPa
+// levels.
+func totalTotals(l850, l500 Level) float64 {
+	return l850.Temp.C() + l850.DewPoint.C() - 2*l500.Temp.C()
+}
+
+// Showalter returns the Showalter index in kelvin, the lifted index
+// of a parcel lifted from 850 hPa.
+//
+// An error is returned if the sounding does not span 850 to 500 hPa
+// or the dew point at 850 hPa is invalid.
+func (s Sounding) Showalter() (float64, error) {
+	l, err := s.indexLevels("showalter", 850, 500)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := dewPoints("showalter", l[0]); err != nil {
+		return 0, err
+	}
+
+	a, err := s.Lift(Parcel{Pressure: l[0].Pressure, Temp: l[0].Temp, DewPoint: l[0].DewPoint})
+	if err != nil {
+		return 0, err
+	}
+
+	return a.LiftedIndex, nil
+}
+
+// SWEAT returns the Severe Weather Threat index of Miller (1972):
+//
+//	SWEAT = 12·Td850 + 20·(TT - 49) + 2·f850 + f500 + 125·(sin(d500 - d850) + 0.2)
+//
+// where f is the wind speed in knots and d the wind direction. A
+// negative dew point or Total Totals below 49 contribute nothing.
+// The shear term is only included if the 850 hPa wind is from 130 to
+// 250 degrees, the 500 hPa wind is from 210 to 310 degrees and veers
+// from the 850 hPa wind, and both are at least 15 knots. Values above
+// 300 indicate a potential for severe thunderstorms and above 400 for
+// tornadoes.
+//
+// An error is returned if the sounding does not span 850 to 500 hPa
+// or the dew point or either wind is invalid.
+func (s Sounding) SWEAT() (float64, error) {
+	l, err := s.indexLevels("sweat", 850, 500)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := dewPoints("sweat", l[0]); err != nil {
+		return 0, err
+	}
+
+	w850, w500 := l[0].Wind, l[1].Wind
+	if !w850.Valid() || !w500.Valid() {
+		return 0, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid wind at 850 or 500 hPa", "sweat")
+	}
+
+	f850, f500 := w850.Speed().Kts(), w500.Speed().Kts()
+	d850 := w850.Direction().Degrees().Degrees()
+	d500 := w500.Direction().Degrees().Degrees()
+
+	sweat := 12*math.Max(l[0].DewPoint.C(), 0) +
+		20*math.Max(totalTotals(l[0], l[1])-49, 0) +
+		2*f850 + f500
+
+	if d850 >= 130 && d850 <= 250 && d500 >= 210 && d500 <= 310 && d500 > d850 && f850 >= 15 && f500 >= 15 {
+		sweat += 125 * (math.Sin((d500-d850)*math.Pi/180) + 0.2)
+	}
+
+	return sweat, nil
+}
+
+// PrecipitableWater returns the depth of liquid water if all of the
+// water vapor in the sounding condensed. Levels without a dew point
+// are taken to be dry. The result is in meters.
+//
+// An error is returned if no level has a dew point.
+func (s Sounding) PrecipitableWater() (wx.Distance, error) {
+	var (
+		sum   float64
+		moist bool
+	)
+
+	q := make([]float64, len(s.levels))
+	for i, l := range s.levels {
+		if !l.DewPoint.Valid() {
+			continue
+		}
+
+		if w := mixingRatio(l.DewPoint.K(), l.Pressure.HPa()); !math.IsNaN(w) {
+			q[i] = w / (1 + w)
+			moist = true
+		}
+	}
+
+	if !moist {
+		return wx.Distance{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "no valid dew points", "precipitable water")
+	}
+
+	for i := 1; i < len(s.levels); i++ {
+		dp := (s.levels[i-1].Pressure.HPa() - s.levels[i].Pressure.HPa()) * 100
+		sum += (q[i-1] + q[i]) / 2 * dp
+	}
+
+	return wx.NewDistance(sum/(gravity*waterDensity), wx.Meters), nil
+}
+
+// FreezingLevel returns the lowest level at which the temperature
+// falls to 0 °C, interpolated between the levels of the sounding. It
+// is the surface if the surface is at or below freezing. The result
+// is false if the sounding is above freezing up to its top.
+func (s Sounding) FreezingLevel() (Level, bool) {
+	return s.crossing(func(l Level) (float64, bool) {
+		return l.Temp.C(), true
+	})
+}
+
+// WetBulbZero returns the lowest level at which the psychrometric
+// wet-bulb temperature falls to 0 °C, interpolated between the levels
+// of the sounding. It is the surface if the surface wet bulb is at or
+// below freezing. The result is false if the wet bulb is above
+// freezing up to the highest level with a dew point.
+func (s Sounding) WetBulbZero() (Level, bool) {
+	return s.crossing(func(l Level) (float64, bool) {
+		if !l.DewPoint.Valid() {
+			return 0, false
+		}
+
+		rh := wx.RelativeHumidity(l.Temp, l.DewPoint, formulation)
+		wb := wx.WetBulb(l.Temp, rh, l.Pressure, formulation)
+
+		return wb.C(), wb.Valid()
+	})
+}
+
+// crossing returns the lowest level at which the value that f
+// returns for the levels falls to zero. The search stops at the
+// first level for which f is false.
+func (s Sounding) crossing(f func(l Level) (float64, bool)) (Level, bool) {
+	var prev float64
+	for i, l := range s.levels {
+		v, ok := f(l)
+		if !ok {
+			return Level{}, false
+		}
+
+		if v <= 0 {
+			if i == 0 {
+				return l, true
+			}
+
+			below := s.levels[i-1].Pressure.HPa()
+			lnp := math.Log(below) + prev/(prev-v)*(math.Log(l.Pressure.HPa())-math.Log(below))
+
+			return s.at(math.Exp(lnp))
+		}
+
+		prev = v
+	}
+
+	return Level{}, false
+}
+
+// LapseRate returns the rate at which the temperature decreases with
+// height between two pressures in K/km. It is positive when the
+// temperature falls with height.
+//
+// An error is returned if the pressures are equal or outside of the
+// sounding, or the sounding has no heights at either pressure.
+func (s Sounding) LapseRate(bottom, top wx.Pressure) (float64, error) {
+	if !bottom.Valid() || !top.Valid() || bottom.HPa() == top.HPa() {
+		return 0, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid or equal pressures", "lapse rate")
+	}
+
+	l, err := s.indexLevels("lapse rate", bottom.HPa(), top.HPa())
+	if err != nil {
+		return 0, err
+	}
+
+	if !l[0].Height.Valid() || !l[1].Height.Valid() {
+		return 0, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid height", "lapse rate")
+	}
+
+	dz := (l[1].Height.M() - l[0].Height.M()) / 1000
+
+	return (l[0].Temp.K() - l[1].Temp.K()) / dz, nil
+}
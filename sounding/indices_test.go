@@ -0,0 +1,239 @@
+package sounding
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestSounding_Indices(t *testing.T) {
+	t.Parallel()
+
+	s := convective(t)
+
+	tt := []struct {
+		name string
+		f    func() (float64, error)
+		want float64
+	}{
+		// (18 - -10) + 13 - (8 - -5)
+		{"k-index", s.KIndex, 28},
+		// 18 + 13 - 2·-10
+		{"total totals", s.TotalTotals, 51},
+		{"showalter", s.Showalter, -2.54},
+		{"lapse rate 700-500 hPa", func() (float64, error) {
+			return s.LapseRate(wx.NewPressure(700, wx.HPa), wx.NewPressure(500, wx.HPa))
+		}, 18 / 2.67},
+		{"lapse rate reversed", func() (float64, error) {
+			return s.LapseRate(wx.NewPressure(500, wx.HPa), wx.NewPressure(700, wx.HPa))
+		}, 18 / 2.67},
+		{"lapse rate between levels", func() (float64, error) {
+			return s.LapseRate(wx.NewPressure(1000, wx.HPa).ToInHg(), wx.NewPressure(925, wx.HPa))
+		}, 10.23},
+		{"precipitable water in millimeters", func() (float64, error) {
+			pw, err := s.PrecipitableWater()
+			return pw.M() * 1000, err
+		}, 35.22},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.f()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(got, tc.want, 0.01) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	// The Showalter index is the lifted index of the 850 hPa parcel.
+	l, _ := s.At(wx.NewPressure(850, wx.HPa))
+	a, err := s.Lift(Parcel{Pressure: l.Pressure, Temp: l.Temp, DewPoint: l.DewPoint})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if si, _ := s.Showalter(); si != a.LiftedIndex {
+		t.Errorf("expected %v, got %v", a.LiftedIndex, si)
+	}
+}
+
+func TestSounding_SWEAT(t *testing.T) {
+	t.Parallel()
+
+	wind := func(deg, kts float64) wx.Wind {
+		return wx.NewWind(wx.NewWindDirection(deg), wx.NewVelocity(kts, wx.Kts))
+	}
+
+	sounding := func(w850, w500 wx.Wind, td850 float64) Sounding {
+		s, err := New(
+			Level{Pressure: wx.NewPressure(1000, wx.HPa), Temp: wx.NewTemp(25, wx.Celsius), DewPoint: wx.NewTemp(18, wx.Celsius), Wind: wind(180, 10)},
+			Level{Pressure: wx.NewPressure(850, wx.HPa), Temp: wx.NewTemp(18, wx.Celsius), DewPoint: wx.NewTemp(td850, wx.Celsius), Wind: w850},
+			Level{Pressure: wx.NewPressure(500, wx.HPa), Temp: wx.NewTemp(-10, wx.Celsius), DewPoint: wx.NewTemp(-25, wx.Celsius), Wind: w500},
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return s
+	}
+
+	tt := []struct {
+		name string
+		s    Sounding
+		want float64
+	}{
+		// 12·13 + 20·(51 - 49) + 2·30 + 50 + 125·(sin 60° + 0.2)
+		{"veering", sounding(wind(200, 30), wind(260, 50), 13), 306 + 125*(math.Sin(math.Pi/3)+0.2)},
+		{"850 hPa wind out of range", sounding(wind(100, 30), wind(260, 50), 13), 306},
+		{"backing", sounding(wind(250, 30), wind(220, 50), 13), 306},
+		{"weak 500 hPa wind", sounding(wind(200, 30), wind(260, 10), 13), 156 + 40 + 60 + 10},
+		// The dew point and total totals terms are not negative.
+		{"dry", sounding(wind(200, 30), wind(260, 50), -20), 110 + 125*(math.Sin(math.Pi/3)+0.2)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.s.SWEAT()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(got, tc.want, 1e-9) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := sounding(wx.Wind{}, wind(260, 50), 13).SWEAT(); !errors.Is(err, wx.ErrInvalidMeasurement) {
+		t.Errorf("expected %v, got %v", wx.ErrInvalidMeasurement, err)
+	}
+}
+
+func TestSounding_Levels(t *testing.T) {
+	t.Parallel()
+
+	s := convective(t)
+
+	tt := []struct {
+		name     string
+		f        func() (Level, bool)
+		pressure float64
+		height   float64
+		ok       bool
+	}{
+		{"freezing level at a level", s.FreezingLevel, 600, 4370, true},
+		{"wet-bulb zero", s.WetBulbZero, 672.76, 3449.28, true},
+		{"freezing at the surface", profile(t, [3]float64{1000, -5, -8}, [3]float64{850, -10, -15}).FreezingLevel, 1000, 0, true},
+		{"freezing between levels", profile(t, [3]float64{1000, 10, 0}, [3]float64{800, -10, -20}).FreezingLevel, 894.43, 0, true},
+		{"above freezing", profile(t, [3]float64{1000, 30, 20}, [3]float64{850, 20, 10}).FreezingLevel, 0, 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l, ok := tc.f()
+			if ok != tc.ok {
+				t.Fatalf("expected %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if !tests.CloseEnough(l.Pressure.HPa(), tc.pressure, 0.01) {
+				t.Errorf("expected %v hPa, got %v", tc.pressure, l.Pressure)
+			}
+
+			if tc.height != 0 && !tests.CloseEnough(l.Height.M(), tc.height, 0.01) {
+				t.Errorf("expected %v m, got %v", tc.height, l.Height)
+			}
+		})
+	}
+
+	// The wet bulb is never warmer than the temperature, so the
+	// wet-bulb zero is at or below the freezing level.
+	fl, _ := s.FreezingLevel()
+	wbz, _ := s.WetBulbZero()
+	if wbz.Height.M() > fl.Height.M() {
+		t.Errorf("expected the wet-bulb zero below %v, got %v", fl.Height, wbz.Height)
+	}
+}
+
+func TestSounding_IndexErrors(t *testing.T) {
+	t.Parallel()
+
+	shallow := profile(t, [3]float64{1000, 30, 20}, [3]float64{700, 10, 0})
+	noDewPoint, err := New(
+		Level{Pressure: wx.NewPressure(1000, wx.HPa), Temp: wx.NewTemp(20, wx.Celsius)},
+		Level{Pressure: wx.NewPressure(850, wx.HPa), Temp: wx.NewTemp(10, wx.Celsius)},
+		Level{Pressure: wx.NewPressure(500, wx.HPa), Temp: wx.NewTemp(-10, wx.Celsius)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tt := []struct {
+		name string
+		f    func() (float64, error)
+		kind error
+	}{
+		{"k-index too shallow", shallow.KIndex, wx.ErrOutOfDomain},
+		{"total totals too shallow", shallow.TotalTotals, wx.ErrOutOfDomain},
+		{"showalter too shallow", shallow.Showalter, wx.ErrOutOfDomain},
+		{"sweat too shallow", shallow.SWEAT, wx.ErrOutOfDomain},
+		{"k-index without dew point", noDewPoint.KIndex, wx.ErrInvalidMeasurement},
+		{"total totals without dew point", noDewPoint.TotalTotals, wx.ErrInvalidMeasurement},
+		{"showalter without dew point", noDewPoint.Showalter, wx.ErrInvalidMeasurement},
+		{"lapse rate without heights", func() (float64, error) {
+			return shallow.LapseRate(wx.NewPressure(1000, wx.HPa), wx.NewPressure(700, wx.HPa))
+		}, wx.ErrInvalidMeasurement},
+		{"lapse rate of one level", func() (float64, error) {
+			return shallow.LapseRate(wx.NewPressure(850, wx.HPa), wx.NewPressure(850, wx.HPa))
+		}, wx.ErrInvalidMeasurement},
+		{"lapse rate outside", func() (float64, error) {
+			return shallow.LapseRate(wx.NewPressure(850, wx.HPa), wx.NewPressure(500, wx.HPa))
+		}, wx.ErrOutOfDomain},
+		{"precipitable water without dew points", func() (float64, error) {
+			pw, err := noDewPoint.PrecipitableWater()
+			return pw.M(), err
+		}, wx.ErrInvalidMeasurement},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.f(); !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+
+	// Levels without a dew point are dry.
+	dry, err := New(
+		Level{Pressure: wx.NewPressure(1000, wx.HPa), Temp: wx.NewTemp(20, wx.Celsius), DewPoint: wx.NewTemp(10, wx.Celsius)},
+		Level{Pressure: wx.NewPressure(900, wx.HPa), Temp: wx.NewTemp(10, wx.Celsius)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pw, err := dry.PrecipitableWater()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Half of the specific humidity at the surface over 100 hPa.
+	q, _ := wx.SpecificHumidity(wx.NewTemp(10, wx.Celsius), wx.NewPressure(1000, wx.HPa), formulation)
+	if want := q / 2 * 10000 / (gravity * waterDensity); !tests.CloseEnough(pw.M(), want, 1e-12) {
+		t.Errorf("expected %v m, got %v", want, pw)
+	}
+
+	if _, ok := dry.WetBulbZero(); ok {
+		t.Errorf("expected no wet-bulb zero without dew points")
+	}
+}
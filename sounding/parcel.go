@@ -0,0 +1,419 @@
+package sounding
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-wx/wx"
+)
+
+const (
+	// mixedLayerDepth is the depth in hPa of the mixed layer above
+	// the surface.
+	mixedLayerDepth = 100.0
+	// mostUnstableDepth is the depth in hPa above the surface searched
+	// for the most unstable parcel.
+	mostUnstableDepth = 300.0
+	// liftedIndexPressure is the pressure in hPa of the lifted index.
+	liftedIndexPressure = 500.0
+	// maxStep is the largest pressure step in hPa used to integrate
+	// the buoyancy of a parcel.
+	maxStep = 5.0
+)
+
+// ParcelType identifies the air lifted through a sounding.
+type ParcelType int
+
+// Parcel types.
+const (
+	// Surface is the air at the lowest level of the sounding.
+	Surface ParcelType = iota
+	// MixedLayer is air with the mean potential temperature and
+	// mixing ratio of the lowest 100 hPa of the sounding, starting at
+	// the surface.
+	MixedLayer
+	// MostUnstable is the air with the highest equivalent potential
+	// temperature in the lowest 300 hPa of the sounding.
+	MostUnstable
+)
+
+// String returns the string representation of the parcel type.
+func (t ParcelType) String() string {
+	switch t {
+	case Surface:
+		return "surface"
+	case MixedLayer:
+		return "mixed layer"
+	case MostUnstable:
+		return "most unstable"
+	}
+
+	return ""
+}
+
+// Parcel is the air lifted through a sounding.
+type Parcel struct {
+	// Pressure is the pressure the parcel is lifted from.
+	Pressure wx.Pressure
+	// Temp is the temperature of the parcel.
+	Temp wx.Temp
+	// DewPoint is the dew point of the parcel.
+	DewPoint wx.Temp
+}
+
+// Point is a level a lifted parcel reaches. A point the parcel does
+// not reach has an invalid pressure.
+type Point struct {
+	// Pressure is the pressure of the point.
+	Pressure wx.Pressure
+	// Height is the height of the point above mean sea level. It is
+	// invalid if the sounding has no heights around the point.
+	Height wx.Altitude
+	// Temp is the temperature of the parcel at the point.
+	Temp wx.Temp
+}
+
+// Valid returns true if the parcel reaches the point.
+func (p Point) Valid() bool {
+	return p.Pressure.Valid()
+}
+
+// Analysis is the result of lifting a parcel through a sounding.
+// Pressures and temperatures are in the units of the parcel.
+type Analysis struct {
+	// Parcel is the lifted parcel.
+	Parcel Parcel
+	// LCL is the lifting condensation level, at which the parcel
+	// becomes saturated.
+	LCL Point
+	// LFC is the level of free convection, above which the parcel is
+	// warmer than the environment.
+	LFC Point
+	// EL is the equilibrium level, above which the rising parcel is
+	// colder than the environment again. It is invalid if the parcel
+	// has no LFC or is buoyant up to the top of the sounding.
+	EL Point
+	// CAPE is the convective available potential energy in J/kg, the
+	// positive buoyant energy of the parcel between the LFC and EL.
+	CAPE float64
+	// CIN is the convective inhibition in J/kg, the negative buoyant
+	// energy of the parcel below the LFC. It is zero or negative.
+	CIN float64
+	// LiftedIndex is the temperature of the environment minus that of
+	// the parcel at 500 hPa in kelvin. It is NaN if the parcel is not
+	// lifted through 500 hPa.
+	LiftedIndex float64
+}
+
+// Parcel returns the parcel of a type.
+//
+// An error is returned if the dew points needed for the parcel are
+// invalid.
+func (s Sounding) Parcel(t ParcelType) (Parcel, error) {
+	switch t {
+	case Surface:
+		return s.surfaceParcel()
+	case MixedLayer:
+		return s.mixedLayerParcel()
+	case MostUnstable:
+		return s.mostUnstableParcel()
+	}
+
+	return Parcel{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "unknown parcel type", "sounding")
+}
+
+// Analyze lifts the parcel of a type through the sounding. See
+// Parcel and Lift for the errors returned.
+func (s Sounding) Analyze(t ParcelType) (Analysis, error) {
+	p, err := s.Parcel(t)
+	if err != nil {
+		return Analysis{}, err
+	}
+
+	return s.Lift(p)
+}
+
+// surfaceParcel returns the parcel at the lowest level.
+func (s Sounding) surfaceParcel() (Parcel, error) {
+	l := s.Surface()
+	if !l.DewPoint.Valid() {
+		return Parcel{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid surface dew point", "sounding")
+	}
+
+	return Parcel{Pressure: l.Pressure, Temp: l.Temp, DewPoint: l.DewPoint}, nil
+}
+
+// mixedLayerParcel returns the parcel with the mean potential
+// temperature and mixing ratio of the mixed layer, weighted by
+// pressure.
+func (s Sounding) mixedLayerParcel() (Parcel, error) {
+	surface := s.Surface()
+	p0 := surface.Pressure.HPa()
+	top := math.Max(p0-mixedLayerDepth, s.Top().Pressure.HPa())
+
+	pressures := []float64{top}
+	for _, l := range s.levels {
+		if p := l.Pressure.HPa(); p > top {
+			pressures = append(pressures, p)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(pressures)))
+
+	var sumTheta, sumW float64
+	var prevTheta, prevW float64
+	for i, p := range pressures {
+		l, _ := s.at(p)
+		if !l.DewPoint.Valid() {
+			return Parcel{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid dew point in the mixed layer", "sounding")
+		}
+
+		theta := potentialTemp(l.Temp.K(), p)
+		w := mixingRatio(l.DewPoint.K(), p)
+		if i > 0 {
+			dp := pressures[i-1] - p
+			sumTheta += (theta + prevTheta) / 2 * dp
+			sumW += (w + prevW) / 2 * dp
+		}
+
+		prevTheta, prevW = theta, w
+	}
+
+	theta, w := prevTheta, prevW
+	if depth := p0 - top; depth > 0 {
+		theta, w = sumTheta/depth, sumW/depth
+	}
+
+	t := dryAdiabat(theta, p0)
+
+	return Parcel{
+		Pressure: surface.Pressure,
+		Temp:     wx.NewTemp(t, wx.Kelvin).To(surface.Temp.Unit()),
+		DewPoint: wx.NewTemp(dewPoint(t, w, p0), wx.Kelvin).To(surface.Temp.Unit()),
+	}, nil
+}
+
+// mostUnstableParcel returns the parcel with the highest equivalent
+// potential temperature near the surface.
+func (s Sounding) mostUnstableParcel() (Parcel, error) {
+	bottom := s.Surface().Pressure.HPa()
+
+	var (
+		parcel Parcel
+		best   = math.Inf(-1)
+	)
+	for _, l := range s.levels {
+		p := l.Pressure.HPa()
+		if p < bottom-mostUnstableDepth {
+			break
+		}
+
+		if !l.DewPoint.Valid() {
+			continue
+		}
+
+		if thetaE := equivalentPotentialTemp(l.Temp.K(), l.DewPoint.K(), p); thetaE > best && !math.IsInf(thetaE, 1) {
+			parcel = Parcel{Pressure: l.Pressure, Temp: l.Temp, DewPoint: l.DewPoint}
+			best = thetaE
+		}
+	}
+
+	if math.IsInf(best, -1) {
+		return Parcel{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "no valid dew points near the surface", "sounding")
+	}
+
+	return parcel, nil
+}
+
+// buoyancy is the virtual temperature difference between a parcel
+// and the environment at a pressure in hPa.
+type buoyancy struct {
+	p, b float64
+}
+
+// Lift lifts a parcel through the sounding from its pressure, dry
+// adiabatically up to its LCL and pseudo-adiabatically above it.
+// The buoyancy of the parcel is the difference between its virtual
+// temperature and that of the environment. Levels of the environment
+// without a dew point are taken to be dry.
+//
+// An error is returned if the parcel is invalid or its pressure is
+// outside of the sounding.
+func (s Sounding) Lift(parcel Parcel) (Analysis, error) {
+	if !parcel.Pressure.Valid() || !parcel.Temp.Valid() || !parcel.DewPoint.Valid() {
+		return Analysis{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "invalid parcel", "sounding")
+	}
+
+	p0 := parcel.Pressure.HPa()
+	if p0 > s.Surface().Pressure.HPa() || p0 <= s.Top().Pressure.HPa() {
+		return Analysis{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "parcel outside of the sounding", "sounding")
+	}
+
+	t0 := parcel.Temp.K()
+	td0 := math.Min(parcel.DewPoint.K(), t0)
+	w0 := mixingRatio(td0, p0)
+	if math.IsNaN(w0) {
+		return Analysis{}, wx.NewWxErrKind(wx.ErrOutOfDomain, "vapor pressure exceeds the parcel pressure", "sounding")
+	}
+
+	theta := potentialTemp(t0, p0)
+	thetaE := equivalentPotentialTemp(t0, td0, p0)
+	tl, pl := lcl(t0, td0, p0)
+	pl = math.Min(pl, p0)
+
+	// parcelAt returns the temperature and dew point of the parcel
+	// at a pressure.
+	parcelAt := func(p float64) (float64, float64) {
+		if p >= pl {
+			t := dryAdiabat(theta, p)
+			return t, dewPoint(t, w0, p)
+		}
+
+		// The parcel is saturated above its LCL.
+		t := moistAdiabat(thetaE, p)
+		return t, t
+	}
+
+	profile := s.buoyancy(p0, pl, parcelAt)
+
+	a := Analysis{
+		Parcel:      parcel,
+		LCL:         s.point(pl, tl, parcel),
+		LiftedIndex: math.NaN(),
+	}
+
+	if liftedIndexPressure <= p0 {
+		if env, ok := s.at(liftedIndexPressure); ok {
+			t, _ := parcelAt(liftedIndexPressure)
+			a.LiftedIndex = env.Temp.K() - t
+		}
+	}
+
+	// The LFC is where the parcel first becomes buoyant at or above
+	// the LCL.
+	lfc := -1
+	for i, b := range profile {
+		if b.p <= pl && b.b > 0 {
+			lfc = i
+			if i > 0 && profile[i-1].p <= pl {
+				lfc = i - 1
+			}
+			break
+		}
+	}
+
+	if lfc < 0 {
+		return a, nil
+	}
+
+	// The EL is where the parcel last stops being buoyant.
+	el := len(profile) - 1
+	for i := len(profile) - 1; i > lfc; i-- {
+		if profile[i].b <= 0 && profile[i-1].b > 0 {
+			el = i
+			break
+		}
+	}
+
+	t, _ := parcelAt(profile[lfc].p)
+	a.LFC = s.point(profile[lfc].p, t, parcel)
+
+	if profile[el].b <= 0 {
+		t, _ := parcelAt(profile[el].p)
+		a.EL = s.point(profile[el].p, t, parcel)
+	}
+
+	a.CAPE = gasConstantDry * integrate(profile[lfc:el+1], true)
+	a.CIN = gasConstantDry * integrate(profile[:lfc+1], false)
+
+	return a, nil
+}
+
+// buoyancy returns the buoyancy of a parcel lifted from p0 with its
+// LCL at pl on a grid of pressures from p0 to the top of the
+// sounding. The grid includes the levels of the sounding, the LCL
+// and the points at which the buoyancy changes sign.
+func (s Sounding) buoyancy(p0, pl float64, parcelAt func(p float64) (float64, float64)) []buoyancy {
+	pressures := []float64{p0, pl}
+	for _, l := range s.levels {
+		if p := l.Pressure.HPa(); p < p0 {
+			pressures = append(pressures, p)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(pressures)))
+
+	var grid []float64
+	for i, p := range pressures {
+		if i > 0 {
+			prev := pressures[i-1]
+			if prev == p || p < s.Top().Pressure.HPa() {
+				continue
+			}
+
+			n := math.Ceil((prev - p) / maxStep)
+			for j := 1.0; j < n; j++ {
+				grid = append(grid, prev-(prev-p)*j/n)
+			}
+		}
+
+		grid = append(grid, p)
+	}
+
+	var profile []buoyancy
+	for _, p := range grid {
+		env, _ := s.at(p)
+
+		te := env.Temp.K()
+		tve := te
+		if env.DewPoint.Valid() {
+			tve = virtualTemp(te, math.Min(env.DewPoint.K(), te), p)
+		}
+
+		tp, tdp := parcelAt(p)
+		b := buoyancy{p: p, b: virtualTemp(tp, tdp, p) - tve}
+
+		// Insert the point at which the buoyancy changes sign,
+		// interpolated linearly in the logarithm of the pressure.
+		if n := len(profile); n > 0 {
+			prev := profile[n-1]
+			if (prev.b < 0 && b.b > 0) || (prev.b > 0 && b.b < 0) {
+				f := prev.b / (prev.b - b.b)
+				lnp := math.Log(prev.p) + f*(math.Log(p)-math.Log(prev.p))
+				profile = append(profile, buoyancy{p: math.Exp(lnp)})
+			}
+		}
+
+		profile = append(profile, b)
+	}
+
+	return profile
+}
+
+// integrate returns the integral of the positive or negative
+// buoyancy over the logarithm of the pressure. The profile changes
+// sign only at points of zero buoyancy.
+func integrate(profile []buoyancy, positive bool) float64 {
+	var sum float64
+	for i := 1; i < len(profile); i++ {
+		area := (profile[i-1].b + profile[i].b) / 2 * math.Log(profile[i-1].p/profile[i].p)
+		if (positive && area > 0) || (!positive && area < 0) {
+			sum += area
+		}
+	}
+
+	return sum
+}
+
+// point returns the point at a pressure in hPa with the parcel
+// temperature t in kelvin in the units of the parcel.
+func (s Sounding) point(p, t float64, parcel Parcel) Point {
+	pt := Point{
+		Pressure: wx.NewPressure(p, wx.HPa).To(parcel.Pressure.Unit()),
+		Temp:     wx.NewTemp(t, wx.Kelvin).To(parcel.Temp.Unit()),
+	}
+
+	if l, ok := s.at(p); ok {
+		pt.Height = l.Height
+	}
+
+	return pt
+}
@@ -0,0 +1,211 @@
+package sounding
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+// profile returns a sounding from rows of pressure in hPa and
+// temperature and dew point in Celsius.
+func profile(t *testing.T, rows ...[3]float64) Sounding {
+	t.Helper()
+
+	levels := make([]Level, len(rows))
+	for i, r := range rows {
+		levels[i] = Level{
+			Pressure: wx.NewPressure(r[0], wx.HPa),
+			Temp:     wx.NewTemp(r[1], wx.Celsius),
+			DewPoint: wx.NewTemp(r[2], wx.Celsius),
+		}
+	}
+
+	s, err := New(levels...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return s
+}
+
+func TestSounding_Analyze(t *testing.T) {
+	t.Parallel()
+
+	// Moist air below 850 hPa under an inversion above a cool surface.
+	elevated := profile(t,
+		[3]float64{1000, 10, 5},
+		[3]float64{950, 12, 6},
+		[3]float64{900, 18, 16},
+		[3]float64{850, 15, 13},
+		[3]float64{700, 2, -6},
+		[3]float64{500, -16, -30},
+		[3]float64{300, -42, -55},
+		[3]float64{200, -56, -70},
+	)
+
+	// Warm and dry aloft.
+	stable := profile(t,
+		[3]float64{1000, 15, 5},
+		[3]float64{850, 10, 0},
+		[3]float64{700, 5, -10},
+		[3]float64{500, -5, -20},
+		[3]float64{300, -20, -40},
+	)
+
+	tt := []struct {
+		name   string
+		s      Sounding
+		parcel ParcelType
+		start  float64
+		lcl    float64
+		lfc    float64
+		el     float64
+		cape   float64
+		cin    float64
+		li     float64
+	}{
+		{"surface", convective(t), Surface, 1000, 864.55, 837.54, 196.04, 2447.08, -5.71, -6.06},
+		{"mixed layer", convective(t), MixedLayer, 1000, 853.76, 795.68, 207.80, 1788.34, -28.71, -4.74},
+		{"most unstable at the surface", convective(t), MostUnstable, 1000, 864.55, 837.54, 196.04, 2447.08, -5.71, -6.06},
+		{"stable surface", elevated, Surface, 1000, 926.60, 0, 0, 0, 0, 13.94},
+		{"elevated most unstable", elevated, MostUnstable, 900, 873.56, 842.84, 233.03, 2340.07, -7.76, -8.19},
+		{"stable", stable, MostUnstable, 700, 554.23, 0, 0, 0, 0, 13.52},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := tc.s.Analyze(tc.parcel)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if a.Parcel.Pressure.HPa() != tc.start {
+				t.Errorf("expected a parcel at %v hPa, got %v", tc.start, a.Parcel.Pressure)
+			}
+
+			if !tests.CloseEnough(a.LCL.Pressure.HPa(), tc.lcl, 0.01) {
+				t.Errorf("expected an LCL at %v hPa, got %v", tc.lcl, a.LCL.Pressure)
+			}
+
+			if a.LFC.Valid() != (tc.lfc != 0) || !tests.CloseEnough(a.LFC.Pressure.HPa(), tc.lfc, 0.01) {
+				t.Errorf("expected an LFC at %v hPa, got %v", tc.lfc, a.LFC.Pressure)
+			}
+
+			if a.EL.Valid() != (tc.el != 0) || !tests.CloseEnough(a.EL.Pressure.HPa(), tc.el, 0.01) {
+				t.Errorf("expected an EL at %v hPa, got %v", tc.el, a.EL.Pressure)
+			}
+
+			if !tests.CloseEnough(a.CAPE, tc.cape, 0.01) || !tests.CloseEnough(a.CIN, tc.cin, 0.01) {
+				t.Errorf("expected %v/%v J/kg, got %v/%v", tc.cape, tc.cin, a.CAPE, a.CIN)
+			}
+
+			if !tests.CloseEnough(a.LiftedIndex, tc.li, 0.01) {
+				t.Errorf("expected a lifted index of %v, got %v", tc.li, a.LiftedIndex)
+			}
+		})
+	}
+}
+
+func TestSounding_Lift(t *testing.T) {
+	t.Parallel()
+
+	s := convective(t)
+
+	// The dry adiabat conserves the potential temperature, so the
+	// parcel temperature at the LCL matches Bolton's formula.
+	a, err := s.Lift(Parcel{
+		Pressure: wx.NewPressure(1000, wx.HPa).ToInHg(),
+		Temp:     wx.NewTemp(86, wx.Fahrenheit),
+		DewPoint: wx.NewTemp(68, wx.Fahrenheit),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.LCL.Pressure.Unit() != wx.InHg || a.LCL.Temp.Unit() != wx.Fahrenheit {
+		t.Errorf("expected inHg and °F, got %v and %v", a.LCL.Pressure, a.LCL.Temp)
+	}
+
+	if !tests.CloseEnough(a.LCL.Temp.C(), 17.67, 0.01) {
+		t.Errorf("expected 17.67 °C, got %v", a.LCL.Temp)
+	}
+
+	// The heights come from the sounding.
+	if !tests.CloseEnough(a.LCL.Height.M(), 1374.53, 0.01) || !a.EL.Height.Valid() {
+		t.Errorf("expected an LCL at 1374.53 m, got %v", a.LCL.Height)
+	}
+
+	// The parcel is at the temperature of the environment at the EL.
+	env, _ := s.At(a.EL.Pressure)
+	if math.Abs(env.Temp.C()-a.EL.Temp.C()) > 1 {
+		t.Errorf("expected about %v at the EL, got %v", env.Temp, a.EL.Temp)
+	}
+
+	// A parcel that does not reach 500 hPa has no lifted index.
+	shallow := profile(t, [3]float64{1000, 30, 20}, [3]float64{700, 10, 0})
+	a, err = shallow.Analyze(Surface)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !math.IsNaN(a.LiftedIndex) {
+		t.Errorf("expected no lifted index, got %v", a.LiftedIndex)
+	}
+
+	// A parcel that is buoyant up to the top has no EL.
+	if !a.LFC.Valid() || a.EL.Valid() || a.CAPE <= 0 {
+		t.Errorf("expected an LFC and CAPE without an EL, got %+v", a)
+	}
+}
+
+func TestSounding_LiftErrors(t *testing.T) {
+	t.Parallel()
+
+	s := convective(t)
+	noDewPoint, err := New(
+		Level{Pressure: wx.NewPressure(1000, wx.HPa), Temp: wx.NewTemp(20, wx.Celsius)},
+		Level{Pressure: wx.NewPressure(500, wx.HPa), Temp: wx.NewTemp(-10, wx.Celsius)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parcel := func(hPa float64) Parcel {
+		return Parcel{Pressure: wx.NewPressure(hPa, wx.HPa), Temp: wx.NewTemp(20, wx.Celsius), DewPoint: wx.NewTemp(10, wx.Celsius)}
+	}
+
+	tt := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"invalid parcel", func() error { _, err := s.Lift(Parcel{}); return err }(), wx.ErrInvalidMeasurement},
+		{"below the surface", func() error { _, err := s.Lift(parcel(1050)); return err }(), wx.ErrOutOfDomain},
+		{"at the top", func() error { _, err := s.Lift(parcel(100)); return err }(), wx.ErrOutOfDomain},
+		{"unknown parcel type", func() error { _, err := s.Analyze(ParcelType(-1)); return err }(), wx.ErrOutOfDomain},
+		{"surface without dew point", func() error { _, err := noDewPoint.Analyze(Surface); return err }(), wx.ErrInvalidMeasurement},
+		{"mixed layer without dew point", func() error { _, err := noDewPoint.Analyze(MixedLayer); return err }(), wx.ErrInvalidMeasurement},
+		{"most unstable without dew point", func() error { _, err := noDewPoint.Analyze(MostUnstable); return err }(), wx.ErrInvalidMeasurement},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, tc.err)
+			}
+		})
+	}
+}
+
+func TestParcelType_String(t *testing.T) {
+	t.Parallel()
+
+	for pt, want := range map[ParcelType]string{Surface: "surface", MixedLayer: "mixed layer", MostUnstable: "most unstable", ParcelType(9): ""} {
+		if got := pt.String(); got != want {
+			t.Errorf("expected %q, got %q", want, got)
+		}
+	}
+}
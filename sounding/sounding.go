@@ -0,0 +1,185 @@
+// Package sounding analyzes vertical profiles of the atmosphere from
+// radiosondes and numerical models built from the wx measurement
+// types.
+//
+// A Sounding is a sequence of levels ordered from the surface
+// upwards. Parcels of air can be lifted through it to find the
+// lifting condensation level, level of free convection, equilibrium
+// level, convective available potential energy and convective
+// inhibition. Stability and moisture indices, such as the K-index,
+// Total Totals and precipitable water, are computed from its levels.
+package sounding
+
+import (
+	"math"
+	"sort"
+
+	"github.com/go-wx/wx"
+)
+
+// Level is a level of a sounding. The pressure and temperature are
+// required. The height, dew point and wind may be invalid when they
+// were not measured, e.g. the dew point in the dry upper atmosphere.
+type Level struct {
+	// Pressure is the pressure of the level.
+	Pressure wx.Pressure
+	// Height is the geopotential height of the level above mean sea
+	// level.
+	Height wx.Altitude
+	// Temp is the air temperature.
+	Temp wx.Temp
+	// DewPoint is the dew point temperature.
+	DewPoint wx.Temp
+	// Wind is the wind at the level.
+	Wind wx.Wind
+}
+
+// Sounding is a vertical profile of the atmosphere.
+type Sounding struct {
+	levels []Level
+}
+
+// New creates a new Sounding from its levels, which may be given in
+// any order. An error is returned if there are fewer than two levels,
+// a level has an invalid pressure or temperature or two levels have
+// the same pressure.
+func New(levels ...Level) (Sounding, error) {
+	if len(levels) < 2 {
+		return Sounding{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "at least two levels are required", "sounding")
+	}
+
+	sorted := make([]Level, len(levels))
+	copy(sorted, levels)
+
+	for _, l := range sorted {
+		if !l.Pressure.Valid() || l.Pressure.HPa() == 0 || !l.Temp.Valid() {
+			return Sounding{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "level with an invalid pressure or temperature", "sounding")
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pressure.HPa() > sorted[j].Pressure.HPa()
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Pressure.HPa() == sorted[i-1].Pressure.HPa() {
+			return Sounding{}, wx.NewWxErrKind(wx.ErrInvalidMeasurement, "levels with the same pressure", "sounding")
+		}
+	}
+
+	return Sounding{levels: sorted}, nil
+}
+
+// Levels returns the levels of the sounding from the surface
+// upwards.
+func (s Sounding) Levels() []Level {
+	levels := make([]Level, len(s.levels))
+	copy(levels, s.levels)
+
+	return levels
+}
+
+// Surface returns the lowest level of the sounding.
+func (s Sounding) Surface() Level {
+	if len(s.levels) == 0 {
+		return Level{}
+	}
+
+	return s.levels[0]
+}
+
+// Top returns the highest level of the sounding.
+func (s Sounding) Top() Level {
+	if len(s.levels) == 0 {
+		return Level{}
+	}
+
+	return s.levels[len(s.levels)-1]
+}
+
+// At returns the level at a pressure, interpolated linearly in the
+// logarithm of the pressure between the levels above and below it.
+// The wind is interpolated by its components. A quantity is invalid
+// if it is invalid at either of the neighboring levels. The result
+// is false if the pressure is outside of the sounding.
+func (s Sounding) At(p wx.Pressure) (Level, bool) {
+	if !p.Valid() {
+		return Level{}, false
+	}
+
+	l, ok := s.at(p.HPa())
+	if !ok {
+		return Level{}, false
+	}
+
+	l.Pressure = p
+	return l, true
+}
+
+// at returns the level at a pressure in hPa.
+func (s Sounding) at(hPa float64) (Level, bool) {
+	if len(s.levels) == 0 || hPa > s.levels[0].Pressure.HPa() || hPa < s.levels[len(s.levels)-1].Pressure.HPa() {
+		return Level{}, false
+	}
+
+	// The first level at or above the pressure.
+	i := sort.Search(len(s.levels), func(i int) bool {
+		return s.levels[i].Pressure.HPa() <= hPa
+	})
+
+	above := s.levels[i]
+	if above.Pressure.HPa() == hPa {
+		return above, true
+	}
+
+	below := s.levels[i-1]
+	f := math.Log(below.Pressure.HPa()/hPa) / math.Log(below.Pressure.HPa()/above.Pressure.HPa())
+
+	return Level{
+		Pressure: wx.NewPressure(hPa, wx.HPa).To(below.Pressure.Unit()),
+		Height:   interpolateAltitude(below.Height, above.Height, f),
+		Temp:     interpolateTemp(below.Temp, above.Temp, f),
+		DewPoint: interpolateTemp(below.DewPoint, above.DewPoint, f),
+		Wind:     interpolateWind(below.Wind, above.Wind, f),
+	}, true
+}
+
+// interpolateTemp returns the temperature the fraction f of the way
+// from a to b in the unit of a.
+func interpolateTemp(a, b wx.Temp, f float64) wx.Temp {
+	if !a.Valid() || !b.Valid() {
+		return wx.Temp{}
+	}
+
+	k := a.K() + f*(b.K()-a.K())
+	return wx.NewTemp(k, wx.Kelvin).To(a.Unit())
+}
+
+// interpolateAltitude returns the altitude the fraction f of the way
+// from a to b in the unit of a.
+func interpolateAltitude(a, b wx.Altitude, f float64) wx.Altitude {
+	if !a.Valid() || !b.Valid() {
+		return wx.Altitude{}
+	}
+
+	m := a.M() + f*(b.M()-a.M())
+	return wx.NewAltitude(m, wx.Meters).To(a.Unit())
+}
+
+// interpolateWind returns the wind the fraction f of the way from a
+// to b in the unit of the speed of a.
+func interpolateWind(a, b wx.Wind, f float64) wx.Wind {
+	unit := a.Speed().Unit()
+
+	au, av, ok := a.Components(unit)
+	if !ok {
+		return wx.Wind{}
+	}
+
+	bu, bv, ok := b.Components(unit)
+	if !ok {
+		return wx.Wind{}
+	}
+
+	return wx.NewWindFromComponents(au+f*(bu-au), av+f*(bv-av), unit)
+}
@@ -0,0 +1,193 @@
+package sounding
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/internal/tests"
+)
+
+// convective returns a warm, moist and conditionally unstable
+// sounding with pressure, temperature, dew point and height levels.
+func convective(t *testing.T) Sounding {
+	t.Helper()
+
+	rows := [][4]float64{
+		{1000, 30, 20, 110},
+		{950, 25, 18, 560},
+		{900, 21, 16, 1030},
+		{850, 18, 13, 1520},
+		{800, 15, 5, 2030},
+		{700, 8, -5, 3130},
+		{600, 0, -15, 4370},
+		{500, -10, -25, 5800},
+		{400, -22, -40, 7400},
+		{300, -38, -55, 9400},
+		{250, -48, -60, 10600},
+		{200, -55, -65, 12000},
+		{150, -58, -70, 13800},
+		{100, -62, -80, 16400},
+	}
+
+	levels := make([]Level, len(rows))
+	for i, r := range rows {
+		levels[i] = Level{
+			Pressure: wx.NewPressure(r[0], wx.HPa),
+			Temp:     wx.NewTemp(r[1], wx.Celsius),
+			DewPoint: wx.NewTemp(r[2], wx.Celsius),
+			Height:   wx.NewAltitude(r[3], wx.Meters),
+		}
+	}
+
+	s, err := New(levels...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return s
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	level := func(hPa, c float64) Level {
+		return Level{Pressure: wx.NewPressure(hPa, wx.HPa), Temp: wx.NewTemp(c, wx.Celsius)}
+	}
+
+	tt := []struct {
+		name   string
+		levels []Level
+		err    bool
+	}{
+		{"unordered", []Level{level(500, -10), level(1000, 15), level(850, 5)}, false},
+		{"one level", []Level{level(1000, 15)}, true},
+		{"invalid pressure", []Level{level(1000, 15), {Temp: wx.NewTemp(5, wx.Celsius)}}, true},
+		{"zero pressure", []Level{level(1000, 15), level(0, -60)}, true},
+		{"invalid temperature", []Level{level(1000, 15), {Pressure: wx.NewPressure(850, wx.HPa)}}, true},
+		{"duplicate pressure", []Level{level(1000, 15), level(850, 5), level(850, 6)}, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(tc.levels...)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if err != nil {
+				if !errors.Is(err, wx.ErrInvalidMeasurement) {
+					t.Errorf("expected %v, got %v", wx.ErrInvalidMeasurement, err)
+				}
+				return
+			}
+
+			levels := s.Levels()
+			for i := 1; i < len(levels); i++ {
+				if levels[i].Pressure.HPa() >= levels[i-1].Pressure.HPa() {
+					t.Errorf("expected decreasing pressures, got %v", levels)
+				}
+			}
+
+			if s.Surface().Pressure.HPa() != 1000 || s.Top().Pressure.HPa() != 500 {
+				t.Errorf("expected 1000 to 500 hPa, got %v to %v", s.Surface().Pressure, s.Top().Pressure)
+			}
+		})
+	}
+
+	// The levels are copied.
+	s := convective(t)
+	s.Levels()[0].Temp = wx.NewTemp(-40, wx.Celsius)
+	if s.Surface().Temp.C() != 30 {
+		t.Errorf("expected the sounding to be unchanged, got %v", s.Surface().Temp)
+	}
+}
+
+func TestSounding_At(t *testing.T) {
+	t.Parallel()
+
+	s := convective(t)
+
+	tt := []struct {
+		name   string
+		p      wx.Pressure
+		temp   float64
+		height float64
+		ok     bool
+	}{
+		{"level", wx.NewPressure(850, wx.HPa), 18, 1520, true},
+		{"surface", wx.NewPressure(1000, wx.HPa), 30, 110, true},
+		{"between levels", wx.NewPressure(750, wx.HPa), 11.62, 2561.65, true},
+		{"in inches of mercury", wx.NewPressure(700, wx.HPa).ToInHg(), 8, 3130, true},
+		{"below the surface", wx.NewPressure(1010, wx.HPa), 0, 0, false},
+		{"above the top", wx.NewPressure(50, wx.HPa), 0, 0, false},
+		{"invalid", wx.Pressure{}, 0, 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l, ok := s.At(tc.p)
+			if ok != tc.ok {
+				t.Fatalf("expected %v, got %v", tc.ok, ok)
+			}
+
+			if !ok {
+				return
+			}
+
+			if l.Pressure != tc.p {
+				t.Errorf("expected %v, got %v", tc.p, l.Pressure)
+			}
+
+			if !tests.CloseEnough(l.Temp.C(), tc.temp, 0.01) || l.Temp.Unit() != wx.Celsius {
+				t.Errorf("expected %v °C, got %v", tc.temp, l.Temp)
+			}
+
+			if !tests.CloseEnough(l.Height.M(), tc.height, 0.01) {
+				t.Errorf("expected %v m, got %v", tc.height, l.Height)
+			}
+		})
+	}
+}
+
+func TestSounding_AtMissing(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(
+		Level{
+			Pressure: wx.NewPressure(1000, wx.HPa),
+			Height:   wx.NewAltitude(-50, wx.Meters),
+			Temp:     wx.NewTemp(10, wx.Celsius),
+			DewPoint: wx.NewTemp(5, wx.Celsius),
+			Wind:     wx.NewWind(wx.NewWindDirection(180), wx.NewVelocity(10, wx.Kts)),
+		},
+		Level{
+			Pressure: wx.NewPressure(900, wx.HPa),
+			Height:   wx.NewAltitude(810, wx.Meters),
+			Temp:     wx.NewTemp(5, wx.Celsius),
+			Wind:     wx.NewWind(wx.NewWindDirection(270), wx.NewVelocity(10, wx.Kts)),
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	l, ok := s.At(wx.NewPressure(950, wx.HPa))
+	if !ok {
+		t.Fatalf("expected a level")
+	}
+
+	if l.DewPoint.Valid() {
+		t.Errorf("expected a missing dew point, got %v", l.DewPoint)
+	}
+
+	// Heights below sea level are interpolated.
+	if !l.Height.Valid() || l.Height.Unit() != wx.Meters || l.Height.M() < 300 || l.Height.M() > 400 {
+		t.Errorf("expected about 370 m, got %v", l.Height)
+	}
+
+	// The wind is interpolated by its components.
+	if d := l.Wind.Direction().Degrees().Degrees(); !l.Wind.Valid() || d < 220 || d > 230 {
+		t.Errorf("expected a southwesterly wind, got %v", l.Wind)
+	}
+}
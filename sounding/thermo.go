@@ -0,0 +1,103 @@
+package sounding
+
+import (
+	"math"
+
+	"github.com/go-wx/wx"
+)
+
+const (
+	// gasConstantDry is the specific gas constant of dry air in
+	// J/(kg·K).
+	gasConstantDry = 287.04
+	// gravity is the standard acceleration of gravity in m/s².
+	gravity = 9.80665
+)
+
+// formulation is the saturation vapor pressure formulation of the
+// moisture calculations. The Magnus formula is close to the one
+// Bolton (1980) used for the equivalent potential temperature.
+var formulation = wx.Magnus
+
+// The helpers below work in kelvin and hectopascals.
+
+// mixingRatio returns the mixing ratio in kg/kg at a dew point and
+// pressure, or NaN if the vapor pressure is not below the pressure.
+func mixingRatio(td, p float64) float64 {
+	w, err := wx.MixingRatio(wx.NewTemp(td, wx.Kelvin), wx.NewPressure(p, wx.HPa), formulation)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return w
+}
+
+// dewPoint returns the dew point at a temperature and pressure for a
+// mixing ratio, limited to the temperature.
+func dewPoint(t, w, p float64) float64 {
+	td := wx.DewPointFromMixingRatio(w, wx.NewPressure(p, wx.HPa), formulation)
+	if !td.Valid() {
+		return math.NaN()
+	}
+
+	return math.Min(td.K(), t)
+}
+
+// potentialTemp returns the potential temperature at a temperature
+// and pressure.
+func potentialTemp(t, p float64) float64 {
+	return wx.PotentialTemp(wx.NewTemp(t, wx.Kelvin), wx.NewPressure(p, wx.HPa)).K()
+}
+
+// dryAdiabat returns the temperature at a pressure on the dry
+// adiabat with the potential temperature theta.
+func dryAdiabat(theta, p float64) float64 {
+	return wx.DryAdiabat(wx.NewTemp(theta, wx.Kelvin), wx.NewPressure(p, wx.HPa)).K()
+}
+
+// equivalentPotentialTemp returns the equivalent potential
+// temperature at a temperature, dew point and pressure, or +Inf if
+// the vapor pressure is not below the pressure.
+func equivalentPotentialTemp(t, td, p float64) float64 {
+	thetaE, err := wx.EquivalentPotentialTemp(wx.NewTemp(t, wx.Kelvin), wx.NewTemp(td, wx.Kelvin), wx.NewPressure(p, wx.HPa), formulation)
+	if err != nil {
+		return math.Inf(1)
+	}
+
+	return thetaE.K()
+}
+
+// moistAdiabat returns the temperature at a pressure on the
+// pseudo-adiabat with the equivalent potential temperature thetaE,
+// or NaN if saturated air at the pressure cannot reach it.
+func moistAdiabat(thetaE, p float64) float64 {
+	t, err := wx.MoistAdiabat(wx.NewTemp(thetaE, wx.Kelvin), wx.NewPressure(p, wx.HPa), formulation)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return t.K()
+}
+
+// lcl returns the temperature and pressure of the lifting
+// condensation level of air at a temperature, dew point and pressure.
+func lcl(t, td, p float64) (float64, float64) {
+	tl, pl, err := wx.LCL(wx.NewTemp(t, wx.Kelvin), wx.NewTemp(td, wx.Kelvin), wx.NewPressure(p, wx.HPa))
+	if err != nil {
+		return math.NaN(), math.NaN()
+	}
+
+	return tl.K(), pl.HPa()
+}
+
+// virtualTemp returns the virtual temperature at a temperature, dew
+// point and pressure, or the temperature if the vapor pressure is not
+// below the pressure.
+func virtualTemp(t, td, p float64) float64 {
+	tv, err := wx.VirtualTemp(wx.NewTemp(t, wx.Kelvin), wx.NewTemp(td, wx.Kelvin), wx.NewPressure(p, wx.HPa), formulation)
+	if err != nil {
+		return t
+	}
+
+	return tv.K()
+}
@@ -0,0 +1,178 @@
+package wx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+)
+
+// storageUnit returns the unit quantities of the dimension D are
+// stored in: kelvin, meters, pascals and meters per second. Altitudes
+// are stored in meters. A Column stores them in another unit.
+func storageUnit[D Dimension]() D {
+	var unit interface{}
+
+	var zero D
+	switch interface{}(zero).(type) {
+	case TempUnit:
+		unit = Kelvin
+	case DistanceUnit:
+		unit = Meters
+	case altitudeUnit:
+		unit = altitudeUnit{meters}
+	case PressureUnit:
+		unit = Pa
+	case VelocityUnit:
+		unit = Mps
+	default:
+		return zero
+	}
+
+	return unit.(D)
+}
+
+// Value implements driver.Valuer. The quantity is stored as a number
+// in the storage unit of its dimension: kelvin, meters, pascals or
+// meters per second. A missing quantity, such as the zero value, is
+// stored as NULL. Measurements below the lowest valid measurement of
+// their unit, such as a negative distance, are stored as they are.
+func (q Quantity[D]) Value() (driver.Value, error) {
+	return q.value(storageUnit[D]())
+}
+
+// Scan implements sql.Scanner. Numbers are read in the storage unit
+// of the dimension and strings may also be anything accepted by
+// Parse, such as "29.92 inHg". NULL is scanned as an invalid
+// quantity.
+func (q *Quantity[D]) Scan(src interface{}) error {
+	return q.scan(src, storageUnit[D]())
+}
+
+// value returns the quantity as a number in a unit.
+func (q Quantity[D]) value(unit D) (driver.Value, error) {
+	if _, ok := unit.definition(); !ok {
+		return nil, NewWxErrKind(ErrInvalidUnit, "invalid storage unit", "sql value")
+	}
+
+	if q.missing() {
+		return nil, nil
+	}
+
+	return q.In(unit), nil
+}
+
+// scan reads a number in a unit or a string accepted by Parse.
+func (q *Quantity[D]) scan(src interface{}, unit D) error {
+	if _, ok := unit.definition(); !ok {
+		return NewWxErrKind(ErrInvalidUnit, "invalid storage unit", "sql scan")
+	}
+
+	switch s := src.(type) {
+	case nil:
+		*q = Quantity[D]{unit: unit}
+	case float64:
+		*q = NewQuantity(s, unit)
+	case int64:
+		*q = NewQuantity(float64(s), unit)
+	case []byte:
+		return q.scanString(string(s), unit)
+	case string:
+		return q.scanString(s, unit)
+	default:
+		return NewWxErrKind(ErrParse, fmt.Sprintf("cannot scan %T", src), "sql scan")
+	}
+
+	return nil
+}
+
+// scanString scans a number in a unit or a string accepted by Parse.
+func (q *Quantity[D]) scanString(s string, unit D) error {
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		*q = NewQuantity(f, unit)
+		return nil
+	}
+
+	p, err := parse[D](s)
+	if err != nil {
+		return err
+	}
+
+	*q = p
+
+	return nil
+}
+
+// Column is a quantity stored in a database column in a unit other
+// than the storage unit of its dimension, such as temperatures in
+// Celsius or pressures in hPa:
+//
+//	c := wx.Column[wx.PressureUnit]{Unit: wx.HPa}
+//	err := row.Scan(&c)
+//	p := wx.Pressure(c.Quantity)
+type Column[D Dimension] struct {
+	// Quantity is the quantity in the column.
+	Quantity Quantity[D]
+	// Unit is the unit of the numbers in the column.
+	Unit D
+}
+
+// Value implements driver.Valuer. See Quantity.Value.
+func (c Column[D]) Value() (driver.Value, error) {
+	return c.Quantity.value(c.Unit)
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (c *Column[D]) Scan(src interface{}) error {
+	return c.Quantity.scan(src, c.Unit)
+}
+
+// Value implements driver.Valuer. See Quantity.Value.
+func (t Temp) Value() (driver.Value, error) {
+	return t.Quantity().Value()
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (t *Temp) Scan(src interface{}) error {
+	return (*Quantity[TempUnit])(t).Scan(src)
+}
+
+// Value implements driver.Valuer. See Quantity.Value.
+func (d Distance) Value() (driver.Value, error) {
+	return d.Quantity().Value()
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (d *Distance) Scan(src interface{}) error {
+	return (*Quantity[DistanceUnit])(d).Scan(src)
+}
+
+// Value implements driver.Valuer. See Quantity.Value.
+func (p Pressure) Value() (driver.Value, error) {
+	return p.Quantity().Value()
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (p *Pressure) Scan(src interface{}) error {
+	return (*Quantity[PressureUnit])(p).Scan(src)
+}
+
+// Value implements driver.Valuer. See Quantity.Value.
+func (v Velocity) Value() (driver.Value, error) {
+	return v.Quantity().Value()
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (v *Velocity) Scan(src interface{}) error {
+	return (*Quantity[VelocityUnit])(v).Scan(src)
+}
+
+// Value implements driver.Valuer. See Quantity.Value. Altitudes are
+// stored in meters.
+func (a Altitude) Value() (driver.Value, error) {
+	return a.quantity().Value()
+}
+
+// Scan implements sql.Scanner. See Quantity.Scan.
+func (a *Altitude) Scan(src interface{}) error {
+	return (*Quantity[altitudeUnit])(a).Scan(src)
+}
@@ -0,0 +1,184 @@
+package wx
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+// The measurement types are used directly as query arguments and
+// scan destinations.
+var (
+	_ driver.Valuer = Temp{}
+	_ driver.Valuer = Distance{}
+	_ driver.Valuer = Pressure{}
+	_ driver.Valuer = Velocity{}
+	_ sql.Scanner   = (*Temp)(nil)
+	_ sql.Scanner   = (*Distance)(nil)
+	_ sql.Scanner   = (*Pressure)(nil)
+	_ sql.Scanner   = (*Velocity)(nil)
+	_ driver.Valuer = Altitude{}
+	_ sql.Scanner   = (*Altitude)(nil)
+	_ driver.Valuer = Column[TempUnit]{}
+	_ sql.Scanner   = (*Column[TempUnit])(nil)
+)
+
+func TestQuantity_Value(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		v    driver.Valuer
+		want interface{}
+	}{
+		{"temperature in kelvin", NewTemp(0, Celsius), 273.15},
+		{"distance in meters", NewDistance(1, Kilometers), 1000.0},
+		{"pressure in pascals", NewPressure(1013.25, HPa), 101325.0},
+		{"velocity in meters per second", NewVelocity(60, Mpm), 1.0},
+		{"below sea level", NewDistance(-1, Feet), -0.3048},
+		{"altitude in meters", NewAltitude(-1407, Feet), -428.8536},
+		{"missing altitude", Altitude{}, nil},
+		{"below absolute zero", NewTemp(-1, Kelvin), -1.0},
+		{"zero value", Velocity{}, nil},
+		{"column in hectopascals", Column[PressureUnit]{Quantity: NewPressure(29.92, InHg).Quantity(), Unit: HPa}, 1013.2079},
+		{"column in feet", Column[DistanceUnit]{Quantity: NewDistance(-10, Meters).Quantity(), Unit: Feet}, -32.8084},
+		{"column without a quantity", Column[TempUnit]{Unit: Kelvin}, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.v.Value()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.want == nil {
+				if got != nil {
+					t.Errorf("expected NULL, got %v", got)
+				}
+				return
+			}
+
+			f, ok := got.(float64)
+			if !ok || !tests.CloseEnough(f, tc.want.(float64), 1e-4) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestQuantity_Scan(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		src   interface{}
+		want  Pressure
+		valid bool
+		err   bool
+	}{
+		{"float", 101325.0, NewPressure(101325, Pa), true, false},
+		{"integer", int64(101325), NewPressure(101325, Pa), true, false},
+		{"bytes", []byte("101325"), NewPressure(101325, Pa), true, false},
+		{"string with unit", "29.92 inHg", NewPressure(29.92, InHg), true, false},
+		{"null", nil, Pressure{unit: Pa}, false, false},
+		{"negative", -1.0, NewPressure(-1, Pa), false, false},
+		{"unknown unit", "29.92 bananas", Pressure{}, false, true},
+		{"unsupported type", true, Pressure{}, false, true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got Pressure
+			err := got.Scan(tc.src)
+			if (err != nil) != tc.err {
+				t.Fatalf("expected error %v, got %v", tc.err, err)
+			}
+
+			if got != tc.want {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+
+			if got.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, got.Valid())
+			}
+		})
+	}
+}
+
+func TestQuantity_ValueScanRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := NewTemp(-5, Celsius)
+
+	v, err := want.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got Temp
+	if err := got.Scan(v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Unit() != Kelvin || !tests.CloseEnough(got.C(), want.C(), tests.Tolerance) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestColumn_Scan(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		col   Column[TempUnit]
+		src   interface{}
+		want  Temp
+		valid bool
+	}{
+		{"celsius", Column[TempUnit]{Unit: Celsius}, 20.5, NewTemp(20.5, Celsius), true},
+		{"kelvin", Column[TempUnit]{Unit: Kelvin}, int64(300), NewTemp(300, Kelvin), true},
+		{"string with unit", Column[TempUnit]{Unit: Celsius}, "68 °F", NewTemp(68, Fahrenheit), true},
+		{"null", Column[TempUnit]{Unit: Celsius}, nil, Temp{unit: Celsius}, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.col.Scan(tc.src); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := Temp(tc.col.Quantity); got != tc.want || got.Valid() != tc.valid {
+				t.Errorf("expected %#v, got %#v", tc.want, got)
+			}
+		})
+	}
+
+	// A column needs a unit.
+	if err := (&Column[PressureUnit]{}).Scan(1013.25); !errors.Is(err, ErrInvalidUnit) {
+		t.Errorf("expected %v, got %v", ErrInvalidUnit, err)
+	}
+
+	if _, err := (Column[PressureUnit]{Quantity: NewPressure(1013.25, HPa).Quantity()}).Value(); !errors.Is(err, ErrInvalidUnit) {
+		t.Errorf("expected %v, got %v", ErrInvalidUnit, err)
+	}
+
+	// Columns in different units hold the same quantity.
+	want := NewDistance(-12, Meters)
+	v, err := Column[DistanceUnit]{Quantity: want.Quantity(), Unit: Feet}.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := Column[DistanceUnit]{Unit: Feet}
+	if err := c.Scan(v); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := Distance(c.Quantity); !tests.CloseEnough(got.M(), want.M(), tests.Tolerance) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
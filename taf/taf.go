@@ -0,0 +1,478 @@
+// Package taf decodes terminal aerodrome forecasts (TAF) into a
+// timeline of forecast conditions built from the wx measurement types.
+package taf
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-wx/wx"
+	"github.com/go-wx/wx/metar"
+)
+
+var (
+	stationPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{3}$`)
+	issuePattern   = regexp.MustCompile(`^(\d{2})(\d{2})(\d{2})Z$`)
+	periodPattern  = regexp.MustCompile(`^(\d{2})(\d{2})/(\d{2})(\d{2})$`)
+	fromPattern    = regexp.MustCompile(`^FM(\d{2})(\d{2})(\d{2})$`)
+	probPattern    = regexp.MustCompile(`^PROB(30|40)$`)
+	wholePattern   = regexp.MustCompile(`^\d{1,2}$`)
+)
+
+// ChangeType identifies how a group changes the forecast conditions.
+type ChangeType int
+
+// Change types.
+const (
+	// Base is the initial forecast group.
+	Base ChangeType = iota
+	// From (FM) replaces all conditions from its start time.
+	From
+	// Becoming (BECMG) is a gradual change to the reported
+	// elements that completes by the end of its period.
+	Becoming
+	// Temporary (TEMPO) is a temporary fluctuation lasting less
+	// than an hour at a time and less than half of its period.
+	Temporary
+	// Probability (PROB30, PROB40) is a chance of the conditions
+	// occurring, optionally as a temporary fluctuation.
+	Probability
+)
+
+// String returns the string representation of the change type.
+func (c ChangeType) String() string {
+	switch c {
+	case Base:
+		return "BASE"
+	case From:
+		return "FM"
+	case Becoming:
+		return "BECMG"
+	case Temporary:
+		return "TEMPO"
+	case Probability:
+		return "PROB"
+	}
+
+	return ""
+}
+
+// Interval is a forecast time range. The start is inclusive and
+// the end exclusive.
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains returns true if t is within the interval.
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Conditions are the forecast weather elements of a group. Elements
+// that were not reported are left at their zero (invalid) values.
+type Conditions struct {
+	// Wind is the forecast surface wind.
+	Wind metar.Wind
+	// Visibility is the forecast prevailing visibility.
+	Visibility metar.Visibility
+	// Weather holds the forecast weather phenomena.
+	Weather []metar.Weather
+	// NoSignificantWeather is true if the group contained NSW,
+	// ending previously forecast weather.
+	NoSignificantWeather bool
+	// Sky holds the forecast sky condition layers.
+	Sky []metar.SkyCondition
+}
+
+// merge returns c with the elements reported in next applied on top.
+func (c Conditions) merge(next Conditions) Conditions {
+	if next.Wind.Speed.Valid() {
+		c.Wind = next.Wind
+	}
+
+	if next.Visibility.Distance.Valid() || next.Visibility.CAVOK {
+		c.Visibility = next.Visibility
+	}
+
+	if next.NoSignificantWeather {
+		c.Weather = nil
+		c.NoSignificantWeather = true
+	}
+
+	if len(next.Weather) > 0 {
+		c.Weather = next.Weather
+		c.NoSignificantWeather = false
+	}
+
+	if len(next.Sky) > 0 {
+		c.Sky = next.Sky
+	}
+
+	// CAVOK implies no significant weather or cloud.
+	if next.Visibility.CAVOK {
+		c.Weather = nil
+		c.Sky = nil
+	}
+
+	return c
+}
+
+// Change is a single forecast group: the base forecast or a
+// FM, BECMG, TEMPO or PROB change group.
+type Change struct {
+	Interval
+	Conditions
+	// Type is the kind of change.
+	Type ChangeType
+	// Probability is the percent probability of a PROB group
+	// and zero otherwise.
+	Probability int
+	// Tempo is true for a PROB group qualified by TEMPO.
+	Tempo bool
+}
+
+// Period is a span of the timeline with constant prevailing conditions.
+type Period struct {
+	Interval
+	Conditions
+}
+
+// Forecast is a decoded TAF.
+type Forecast struct {
+	// Station is the four letter ICAO station identifier.
+	Station string
+	// Issued is the issue time of the forecast.
+	Issued time.Time
+	// Amended is true for an amended forecast (AMD).
+	Amended bool
+	// Corrected is true for a corrected forecast (COR).
+	Corrected bool
+	// Valid is the validity period of the forecast.
+	Valid Interval
+	// Changes holds the base forecast followed by the change
+	// groups in the order reported.
+	Changes []Change
+	// Remarks is the raw text following RMK.
+	Remarks string
+	// Unparsed holds the groups that could not be decoded.
+	Unparsed []string
+	// Raw is the original forecast text.
+	Raw string
+}
+
+// Parse decodes a raw TAF.
+//
+// TAF times carry only the day of the month, so ref is used to
+// determine the month and year of the issue time; pass a time near
+// when the forecast was issued, such as the current time.
+func Parse(raw string, ref time.Time) (Forecast, error) {
+	f := Forecast{Raw: raw}
+
+	fields := strings.Fields(strings.TrimSuffix(strings.TrimSpace(raw), "="))
+	for i, field := range fields {
+		if field == "RMK" {
+			f.Remarks = strings.Join(fields[i+1:], " ")
+			fields = fields[:i]
+			break
+		}
+	}
+
+	pos := 0
+	peek := func() string {
+		if pos >= len(fields) {
+			return ""
+		}
+		return fields[pos]
+	}
+
+	if peek() == "TAF" {
+		pos++
+	}
+
+	for peek() == "AMD" || peek() == "COR" {
+		f.Amended = f.Amended || peek() == "AMD"
+		f.Corrected = f.Corrected || peek() == "COR"
+		pos++
+	}
+
+	if !stationPattern.MatchString(peek()) {
+		return f, wx.NewWxErrKind(wx.ErrParse, "missing station identifier", "taf")
+	}
+	f.Station = peek()
+	pos++
+
+	m := issuePattern.FindStringSubmatch(peek())
+	if m == nil {
+		return f, wx.NewWxErrKind(wx.ErrParse, "missing issue time", "taf")
+	}
+	issue := metar.ObservationTime{Day: atoi(m[1]), Hour: atoi(m[2]), Minute: atoi(m[3])}
+	f.Issued = issue.Resolve(ref)
+	pos++
+
+	valid, ok := parsePeriod(peek(), f.Issued)
+	if !ok {
+		return f, wx.NewWxErrKind(wx.ErrParse, "missing validity period", "taf")
+	}
+	f.Valid = valid
+	pos++
+
+	current := Change{Type: Base, Interval: valid}
+	for pos < len(fields) {
+		field := fields[pos]
+		pos++
+
+		switch {
+		case fromPattern.MatchString(field):
+			f.Changes = append(f.Changes, current)
+
+			m := fromPattern.FindStringSubmatch(field)
+			start := resolve(f.Issued, atoi(m[1]), atoi(m[2]), atoi(m[3]))
+			current = Change{Type: From, Interval: Interval{Start: start, End: valid.End}}
+		case field == "BECMG", field == "TEMPO", probPattern.MatchString(field):
+			f.Changes = append(f.Changes, current)
+			current = Change{}
+
+			switch {
+			case field == "BECMG":
+				current.Type = Becoming
+			case field == "TEMPO":
+				current.Type = Temporary
+			default:
+				current.Type = Probability
+				current.Probability = atoi(field[4:])
+				if peek() == "TEMPO" {
+					current.Tempo = true
+					pos++
+				}
+			}
+
+			period, ok := parsePeriod(peek(), f.Issued)
+			if !ok {
+				return f, wx.NewWxErrKind(wx.ErrParse, "missing period for "+current.Type.String()+" group", "taf")
+			}
+			current.Interval = period
+			pos++
+		default:
+			// Statute mile visibility may be split into a whole number
+			// and a fraction (e.g. "1 1/2SM").
+			if wholePattern.MatchString(field) && strings.HasSuffix(peek(), "SM") {
+				if v, ok := metar.ParseVisibility(field + " " + peek()); ok {
+					current.Visibility = v
+					pos++
+					continue
+				}
+			}
+
+			if !current.decodeGroup(field) {
+				f.Unparsed = append(f.Unparsed, field)
+			}
+		}
+	}
+	f.Changes = append(f.Changes, current)
+
+	// A FM group ends where the next one starts.
+	var last *Change
+	for i := range f.Changes {
+		c := &f.Changes[i]
+		if c.Type != From && c.Type != Base {
+			continue
+		}
+
+		if last != nil {
+			last.End = c.Start
+		}
+		last = c
+	}
+
+	return f, nil
+}
+
+// decodeGroup decodes a single weather group into the change and
+// returns false if the group was not recognized.
+func (c *Change) decodeGroup(field string) bool {
+	if field == "CAVOK" {
+		c.Visibility = metar.Visibility{
+			Distance:    wx.NewDistance(10, wx.Kilometers),
+			GreaterThan: true,
+			CAVOK:       true,
+		}
+		return true
+	}
+
+	if field == "NSW" {
+		c.NoSignificantWeather = true
+		return true
+	}
+
+	if w, ok := metar.ParseWind(field); ok {
+		c.Wind = w
+		return true
+	}
+
+	if v, ok := metar.ParseVisibility(field); ok {
+		c.Visibility = v
+		return true
+	}
+
+	if s, ok := metar.ParseSky(field); ok {
+		c.Sky = append(c.Sky, s)
+		return true
+	}
+
+	if w, ok := metar.ParseWeather(field); ok {
+		c.Weather = append(c.Weather, w)
+		return true
+	}
+
+	return false
+}
+
+// Timeline returns the prevailing conditions over the validity
+// period as consecutive periods. The base forecast and FM groups
+// replace the conditions, and BECMG groups apply their reported
+// elements from the end of their transition period. TEMPO and PROB
+// groups do not change the prevailing conditions.
+func (f Forecast) Timeline() []Period {
+	// Collect every instant the prevailing conditions may change.
+	boundaries := []time.Time{f.Valid.End}
+	for _, c := range f.Changes {
+		switch c.Type {
+		case Base, From:
+			boundaries = append(boundaries, c.Start)
+		case Becoming:
+			boundaries = append(boundaries, c.End)
+		}
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	var timeline []Period
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, end := boundaries[i], boundaries[i+1]
+		if !start.Before(end) || start.Before(f.Valid.Start) {
+			continue
+		}
+
+		timeline = append(timeline, Period{
+			Interval:   Interval{Start: start, End: end},
+			Conditions: f.prevailing(start),
+		})
+	}
+
+	return timeline
+}
+
+// prevailing returns the prevailing conditions at t.
+func (f Forecast) prevailing(t time.Time) Conditions {
+	var c Conditions
+	for _, ch := range f.Changes {
+		switch ch.Type {
+		case Base, From:
+			if !ch.Start.After(t) {
+				c = ch.Conditions
+			}
+		case Becoming:
+			if !ch.End.After(t) {
+				c = c.merge(ch.Conditions)
+			}
+		}
+	}
+
+	return c
+}
+
+// At returns the forecast in effect at t: the prevailing conditions
+// and any TEMPO, PROB or in-progress BECMG groups that may apply.
+// It returns false if t is outside of the validity period.
+func (f Forecast) At(t time.Time) (Conditions, []Change, bool) {
+	if !f.Valid.Contains(t) {
+		return Conditions{}, nil, false
+	}
+
+	var possible []Change
+	for _, ch := range f.Changes {
+		switch ch.Type {
+		case Becoming, Temporary, Probability:
+			if ch.Contains(t) {
+				possible = append(possible, ch)
+			}
+		}
+	}
+
+	return f.prevailing(t), possible, true
+}
+
+// parsePeriod decodes a DDHH/DDHH validity period relative to the
+// issue time.
+func parsePeriod(s string, issued time.Time) (Interval, bool) {
+	m := periodPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Interval{}, false
+	}
+
+	start := resolve(issued, atoi(m[1]), atoi(m[2]), 0)
+	end := resolve(issued, atoi(m[3]), atoi(m[4]), 0)
+	if end.Before(start) {
+		end = end.AddDate(0, 1, 0)
+	}
+
+	return Interval{Start: start, End: end}, true
+}
+
+// resolve returns the first time on or after the day before the
+// issue time that falls on the given day, hour and minute. Hour 24
+// is the end of the day.
+func resolve(issued time.Time, day, hour, minute int) time.Time {
+	t := time.Date(issued.Year(), issued.Month(), day, hour, minute, 0, 0, time.UTC)
+	if t.Before(issued.AddDate(0, 0, -1)) {
+		t = time.Date(issued.Year(), issued.Month()+1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	return t
+}
+
+// atoi parses a string of digits that has already been validated
+// by a pattern.
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+
+	return i
+}
+
+// Convert returns the conditions with every measurement converted to
+// the units of the system.
+func (c Conditions) Convert(s wx.UnitSystem) Conditions {
+	c.Wind = c.Wind.Convert(s)
+	c.Visibility = c.Visibility.Convert(s)
+
+	if c.Sky != nil {
+		sky := make([]metar.SkyCondition, len(c.Sky))
+		for i, sc := range c.Sky {
+			sky[i] = sc.Convert(s)
+		}
+		c.Sky = sky
+	}
+
+	return c
+}
+
+// Convert returns the forecast with the conditions of every change
+// group converted to the units of the system.
+func (f Forecast) Convert(s wx.UnitSystem) Forecast {
+	if f.Changes != nil {
+		changes := make([]Change, len(f.Changes))
+		for i, c := range f.Changes {
+			c.Conditions = c.Conditions.Convert(s)
+			changes[i] = c
+		}
+		f.Changes = changes
+	}
+
+	return f
+}
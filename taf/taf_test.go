@@ -0,0 +1,256 @@
+package taf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-wx/wx"
+)
+
+const raw = "TAF AMD KJFK 121730Z 1218/1324 27015G25KT P6SM SCT030 BKN250 " +
+	"FM122200 28010KT 1 1/2SM -SHRA BKN040 " +
+	"TEMPO 1302/1306 3SM -SHRA BKN020 " +
+	"BECMG 1308/1310 31012KT " +
+	"PROB30 TEMPO 1312/1316 TSRA BKN030CB " +
+	"FM131800 VRB03KT CAVOK="
+
+// ref is a reference time shortly after the forecast was issued.
+var ref = time.Date(2023, time.March, 12, 18, 0, 0, 0, time.UTC)
+
+// at returns a time in March 2023.
+func at(day, hour, minute int) time.Time {
+	return time.Date(2023, time.March, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse(raw, ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if f.Station != "KJFK" || !f.Amended || f.Corrected {
+		t.Errorf("unexpected header %+v", f)
+	}
+
+	if !f.Issued.Equal(at(12, 17, 30)) {
+		t.Errorf("expected issue time %v, got %v", at(12, 17, 30), f.Issued)
+	}
+
+	if !f.Valid.Start.Equal(at(12, 18, 0)) || !f.Valid.End.Equal(at(14, 0, 0)) {
+		t.Errorf("unexpected validity %+v", f.Valid)
+	}
+
+	want := []struct {
+		typ         ChangeType
+		start, end  time.Time
+		probability int
+	}{
+		{Base, at(12, 18, 0), at(12, 22, 0), 0},
+		{From, at(12, 22, 0), at(13, 18, 0), 0},
+		{Temporary, at(13, 2, 0), at(13, 6, 0), 0},
+		{Becoming, at(13, 8, 0), at(13, 10, 0), 0},
+		{Probability, at(13, 12, 0), at(13, 16, 0), 30},
+		{From, at(13, 18, 0), at(14, 0, 0), 0},
+	}
+
+	if len(f.Changes) != len(want) {
+		t.Fatalf("expected %d changes, got %d", len(want), len(f.Changes))
+	}
+
+	for i, w := range want {
+		c := f.Changes[i]
+		if c.Type != w.typ || !c.Start.Equal(w.start) || !c.End.Equal(w.end) || c.Probability != w.probability {
+			t.Errorf("change %d: expected %v %v-%v, got %v %v-%v", i, w.typ, w.start, w.end, c.Type, c.Start, c.End)
+		}
+	}
+
+	if !f.Changes[4].Tempo {
+		t.Errorf("expected PROB30 TEMPO group")
+	}
+
+	if f.Changes[1].Visibility.Distance.SM() != 1.5 {
+		t.Errorf("expected 1 1/2SM, got %v", f.Changes[1].Visibility.Distance)
+	}
+
+	if len(f.Unparsed) != 0 {
+		t.Errorf("unexpected unparsed groups %v", f.Unparsed)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		raw  string
+	}{
+		{"empty", ""},
+		{"missing station", "TAF 121730Z 1218/1324 27015KT"},
+		{"missing issue time", "TAF KJFK 1218/1324 27015KT"},
+		{"missing validity", "TAF KJFK 121730Z 27015KT"},
+		{"missing change period", "TAF KJFK 121730Z 1218/1324 27015KT TEMPO 3SM"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Parse(tc.raw, ref); err == nil {
+				t.Errorf("expected an error for %q", tc.raw)
+			}
+		})
+	}
+}
+
+func TestParse_MonthBoundary(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse("TAF EGLL 301700Z 3018/0124 24010KT 9999 SCT020", time.Date(2023, time.April, 30, 17, 5, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := time.Date(2023, time.May, 2, 0, 0, 0, 0, time.UTC)
+	if !f.Valid.End.Equal(want) {
+		t.Errorf("expected validity end %v, got %v", want, f.Valid.End)
+	}
+}
+
+func TestForecast_Timeline(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse(raw, ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timeline := f.Timeline()
+
+	want := []struct {
+		start, end time.Time
+		wind       float64
+		sky        int
+	}{
+		{at(12, 18, 0), at(12, 22, 0), 270, 2},
+		{at(12, 22, 0), at(13, 10, 0), 280, 1},
+		{at(13, 10, 0), at(13, 18, 0), 310, 1},
+		{at(13, 18, 0), at(14, 0, 0), 0, 0},
+	}
+
+	if len(timeline) != len(want) {
+		t.Fatalf("expected %d periods, got %d", len(want), len(timeline))
+	}
+
+	for i, w := range want {
+		p := timeline[i]
+		if !p.Start.Equal(w.start) || !p.End.Equal(w.end) {
+			t.Errorf("period %d: expected %v-%v, got %v-%v", i, w.start, w.end, p.Start, p.End)
+		}
+
+		if p.Wind.Direction.Degrees().Degrees() != w.wind || len(p.Sky) != w.sky {
+			t.Errorf("period %d: unexpected conditions %+v", i, p.Conditions)
+		}
+	}
+
+	// The BECMG group only changes the wind.
+	if timeline[2].Visibility.Distance.SM() != 1.5 || len(timeline[2].Weather) != 1 {
+		t.Errorf("expected BECMG to keep the visibility and weather, got %+v", timeline[2].Conditions)
+	}
+}
+
+func TestForecast_At(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse(raw, ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tt := []struct {
+		name     string
+		t        time.Time
+		ok       bool
+		wind     float64
+		possible []ChangeType
+	}{
+		{"base", at(12, 19, 0), true, 270, nil},
+		{"tempo", at(13, 3, 0), true, 280, []ChangeType{Temporary}},
+		{"becoming", at(13, 9, 0), true, 280, []ChangeType{Becoming}},
+		{"after becoming", at(13, 11, 0), true, 310, nil},
+		{"probability", at(13, 12, 0), true, 310, []ChangeType{Probability}},
+		{"last from", at(13, 23, 59), true, 0, nil},
+		{"before validity", at(12, 17, 0), false, 0, nil},
+		{"after validity", at(14, 0, 0), false, 0, nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c, possible, ok := f.At(tc.t)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if c.Wind.Direction.Degrees().Degrees() != tc.wind {
+				t.Errorf("expected wind from %v, got %v", tc.wind, c.Wind.Direction.Degrees())
+			}
+
+			if len(possible) != len(tc.possible) {
+				t.Fatalf("expected %v possible changes, got %v", tc.possible, possible)
+			}
+
+			for i := range possible {
+				if possible[i].Type != tc.possible[i] {
+					t.Errorf("expected %v, got %v", tc.possible[i], possible[i].Type)
+				}
+			}
+		})
+	}
+}
+
+func TestConditions_Merge(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse("TAF KJFK 121730Z 1218/1324 27015KT 3SM -RA OVC010 BECMG 1300/1302 NSW BECMG 1304/1306 CAVOK", ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c, _, _ := f.At(at(13, 3, 0))
+	if len(c.Weather) != 0 || !c.NoSignificantWeather || len(c.Sky) != 1 {
+		t.Errorf("expected NSW to clear the weather only, got %+v", c)
+	}
+
+	c, _, _ = f.At(at(13, 7, 0))
+	if !c.Visibility.CAVOK || len(c.Sky) != 0 || c.Wind.Speed.Kts() != 15 {
+		t.Errorf("expected CAVOK to clear the sky and keep the wind, got %+v", c)
+	}
+}
+
+func TestForecast_Convert(t *testing.T) {
+	t.Parallel()
+
+	f, err := Parse(raw, ref)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := f.Convert(wx.Metric)
+
+	base := c.Changes[0]
+	if base.Wind.Speed.Unit() != wx.Kph || base.Wind.Gust.Unit() != wx.Kph {
+		t.Errorf("expected wind in km/h, got %+v", base.Wind)
+	}
+
+	if base.Visibility.Distance.Unit() != wx.Kilometers || base.Sky[0].Base.Unit() != wx.Meters {
+		t.Errorf("expected metric visibility and sky, got %+v", base.Conditions)
+	}
+
+	// Change groups without a wind keep an invalid wind.
+	if c.Changes[2].Wind.Speed.Valid() {
+		t.Errorf("expected no wind in TEMPO group, got %+v", c.Changes[2].Wind)
+	}
+
+	if f.Changes[0].Wind.Speed.Unit() != wx.Kts {
+		t.Errorf("expected original forecast in knots, got %+v", f.Changes[0].Wind)
+	}
+}
@@ -7,7 +7,7 @@ type tempType int
 
 // Temperature units.
 const (
-	celsius tempType = iota
+	celsius tempType = iota + 1
 	fahrenheit
 	kelvin
 	rankine
@@ -15,17 +15,7 @@ const (
 
 // String returns the string representation of the temperature unit.
 func (t tempType) String() string {
-	switch t {
-	case celsius:
-		return "°C"
-	case fahrenheit:
-		return "°F"
-	case kelvin:
-		return "K"
-	case rankine:
-		return "R"
-	}
-	return ""
+	return tempUnits[t].symbol
 }
 
 // TempUnit represents a unit of temperature.
@@ -37,6 +27,12 @@ func (t TempUnit) String() string {
 	return t.tempType.String()
 }
 
+// definition returns the registry entry of the temperature unit.
+func (t TempUnit) definition() (unitDefinition, bool) {
+	def, ok := tempUnits[t.tempType]
+	return def, ok
+}
+
 // Temperature units.
 var (
 	Celsius    = TempUnit{celsius}
@@ -64,11 +60,21 @@ const (
 	fToC = 5.0 / 9.0
 )
 
+// tempUnits is the registry of temperature units. The base unit is
+// kelvin and the lowest valid measurement is absolute zero.
+var tempUnits = map[tempType]unitDefinition{
+	celsius:    {symbol: "°C", scale: 1, offset: -absoluteZeroC, min: absoluteZeroC},
+	fahrenheit: {symbol: "°F", scale: fToC, offset: -absoluteZeroF, min: absoluteZeroF},
+	kelvin:     {symbol: "K", scale: 1, min: absoluteZeroK},
+	rankine:    {symbol: "R", scale: fToC, min: absoluteZeroR},
+}
+
 // Temp is a temperature measurement.
-type Temp struct {
-	measurement float64  // All temperatures are stored in the same unit.
-	unit        TempUnit // The unit of the temperature.
-	valid       bool     // True if the temperature is valid.
+type Temp Quantity[TempUnit]
+
+// Quantity returns the temperature as a generic quantity.
+func (t Temp) Quantity() Quantity[TempUnit] {
+	return Quantity[TempUnit](t)
 }
 
 func (t Temp) String() string {
@@ -103,109 +109,80 @@ func (t Temp) Valid() bool {
 	return t.valid
 }
 
+// Unit returns the unit of the temperature.
+func (t Temp) Unit() TempUnit {
+	return t.unit
+}
+
 // NewTemp creates a new temperature measurement.
 func NewTemp(measurement float64, unit TempUnit) Temp {
-	return Temp{measurement,
-		unit,
-		validMeasurement(measurement, unit)}
+	return Temp{
+		measurement: measurement,
+		unit:        unit,
+		valid:       validMeasurement(measurement, unit),
+	}
+}
+
+// NewTempE creates a new temperature measurement like NewTemp, but
+// returns an error describing why the temperature is invalid, such
+// as a RangeError for a temperature below absolute zero.
+func NewTempE(measurement float64, unit TempUnit) (Temp, error) {
+	return NewTemp(measurement, unit), checkQuantity(measurement, unit, "temperature")
 }
 
 // C returns the temperature in Celsius.
 func (t Temp) C() float64 {
-	switch t.unit.tempType {
-	case celsius:
-		return t.measurement
-	case fahrenheit:
-		return (t.measurement - freezingF) * fToC
-	case kelvin:
-		return t.measurement + absoluteZeroC
-	case rankine:
-		return (t.measurement / cToF) + absoluteZeroC
-	}
-
-	return 0
+	return t.In(Celsius)
 }
 
 // ToC converts the temperature to Celsius.
 func (t Temp) ToC() Temp {
-	return NewTemp(t.C(), Celsius)
+	return t.To(Celsius)
 }
 
 // F returns the temperature in Fahrenheit.
 func (t Temp) F() float64 {
-	switch t.unit.tempType {
-	case celsius:
-		return t.measurement*cToF + freezingF
-	case fahrenheit:
-		return t.measurement
-	case kelvin:
-		return t.measurement*cToF + absoluteZeroF
-	case rankine:
-		return t.measurement + absoluteZeroF
-	}
-
-	return 0
+	return t.In(Fahrenheit)
 }
 
 // ToF converts the temperature to Fahrenheit.
 func (t Temp) ToF() Temp {
-	return NewTemp(t.F(), Fahrenheit)
+	return t.To(Fahrenheit)
 }
 
 // K returns the temperature in Kelvin.
 func (t Temp) K() float64 {
-	switch t.unit.tempType {
-	case celsius:
-		return t.measurement - absoluteZeroC
-	case fahrenheit:
-		return (t.measurement - absoluteZeroF) * fToC
-	case kelvin:
-		return t.measurement
-	case rankine:
-		return t.measurement * fToC
-	}
-
-	return 0
+	return t.In(Kelvin)
 }
 
 // ToK converts the temperature to Kelvin.
 func (t Temp) ToK() Temp {
-	return NewTemp(t.K(), Kelvin)
+	return t.To(Kelvin)
 }
 
 // R returns the temperature in Rankine.
 func (t Temp) R() float64 {
-	switch t.unit.tempType {
-	case celsius:
-		return (t.measurement - absoluteZeroC) * cToF
-	case fahrenheit:
-		return t.measurement - absoluteZeroF
-	case kelvin:
-		return t.measurement * cToF
-	case rankine:
-		return t.measurement
-	}
-
-	return 0
+	return t.In(Rankine)
 }
 
 // ToR converts the temperature to Rankine.
 func (t Temp) ToR() Temp {
-	return NewTemp(t.R(), Rankine)
+	return t.To(Rankine)
+}
+
+// In returns the temperature in the specified unit.
+func (t Temp) In(unit TempUnit) float64 {
+	return t.Quantity().In(unit)
+}
+
+// To converts the temperature to the specified unit.
+func (t Temp) To(unit TempUnit) Temp {
+	return Temp(t.Quantity().To(unit))
 }
 
 // validMeasurement returns true if the measurement is valid
 // for the given temperature unit.
 func validMeasurement(measurement float64, unit TempUnit) bool {
-	switch unit {
-	case Celsius:
-		return measurement >= absoluteZeroC
-	case Fahrenheit:
-		return measurement >= absoluteZeroF
-	case Kelvin:
-		return measurement >= absoluteZeroK
-	case Rankine:
-		return measurement >= absoluteZeroR
-	}
-	return false
+	def, ok := unit.definition()
+	return ok && measurement >= def.min
 }
@@ -1,6 +1,10 @@
 package wx
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
 
 func TestTempUnitString(t *testing.T) {
 	tt := []struct {
@@ -125,7 +129,7 @@ func TestTempC(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.C() != tc.want {
+			if !tests.CloseEnough(temp.C(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.C(), tc.want)
 			}
 		})
@@ -149,7 +153,7 @@ func TestTempToC(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.ToC().C() != tc.want {
+			if !tests.CloseEnough(temp.ToC().C(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.ToC().C(), tc.want)
 			}
 		})
@@ -173,7 +177,7 @@ func TestTempF(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.F() != tc.want {
+			if !tests.CloseEnough(temp.F(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.F(), tc.want)
 			}
 		})
@@ -197,7 +201,7 @@ func TestTempToF(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.ToF().F() != tc.want {
+			if !tests.CloseEnough(temp.ToF().F(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.ToF().F(), tc.want)
 			}
 		})
@@ -221,7 +225,7 @@ func TestTempK(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.K() != tc.want {
+			if !tests.CloseEnough(temp.K(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.K(), tc.want)
 			}
 		})
@@ -245,7 +249,7 @@ func TestTempToK(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.ToK().K() != tc.want {
+			if !tests.CloseEnough(temp.ToK().K(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.ToK().K(), tc.want)
 			}
 		})
@@ -269,7 +273,7 @@ func TestTempR(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.R() != tc.want {
+			if !tests.CloseEnough(temp.R(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.R(), tc.want)
 			}
 		})
@@ -293,9 +297,29 @@ func TestTempToR(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			temp := NewTemp(tc.measurement, tc.unit)
-			if temp.ToR().R() != tc.want {
+			if !tests.CloseEnough(temp.ToR().R(), tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", temp.ToR().R(), tc.want)
 			}
 		})
 	}
 }
+
+func TestTempUnit(t *testing.T) {
+	tt := []struct {
+		name string
+		unit TempUnit
+	}{
+		{"celsius", Celsius},
+		{"fahrenheit", Fahrenheit},
+		{"kelvin", Kelvin},
+		{"rankine", Rankine},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NewTemp(0, tc.unit).Unit(); got != tc.unit {
+				t.Errorf("got %v, want %v", got, tc.unit)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package wx
+
+import "math"
+
+// derivativeStep returns the step used to estimate the derivative of
+// a function at x by central differences.
+func derivativeStep(x float64) float64 {
+	return 1e-6 * math.Max(1, math.Abs(x))
+}
+
+// propagate returns the first-order uncertainty of f at x for inputs
+// with the standard uncertainties sigma, assuming the inputs are
+// uncorrelated:
+//
+//	σf² = Σ (∂f/∂xᵢ σᵢ)²
+//
+// The partial derivatives are estimated by central differences.
+func propagate(f func(x []float64) float64, x, sigma []float64) float64 {
+	var sum float64
+	for i := range x {
+		if sigma[i] == 0 {
+			continue
+		}
+
+		h := derivativeStep(x[i])
+
+		xp := append([]float64(nil), x...)
+		xm := append([]float64(nil), x...)
+		xp[i] += h
+		xm[i] -= h
+
+		d := (f(xp) - f(xm)) / (2 * h)
+		sum += d * d * sigma[i] * sigma[i]
+	}
+
+	return math.Sqrt(sum)
+}
+
+// addUncertainties returns the uncertainty of a sum or difference of
+// two uncorrelated measurements.
+func addUncertainties(a, b float64) float64 {
+	return math.Hypot(a, b)
+}
+
+// Uncertainty returns the standard uncertainty of the temperature in
+// its unit, or zero if the uncertainty is not known.
+func (t Temp) Uncertainty() float64 {
+	return t.uncertainty
+}
+
+// WithUncertainty returns the temperature with a standard
+// uncertainty in its unit.
+func (t Temp) WithUncertainty(sigma float64) Temp {
+	return Temp(t.Quantity().WithUncertainty(sigma))
+}
+
+// Uncertainty returns the standard uncertainty of the distance in
+// its unit, or zero if the uncertainty is not known.
+func (d Distance) Uncertainty() float64 {
+	return d.uncertainty
+}
+
+// WithUncertainty returns the distance with a standard uncertainty
+// in its unit.
+func (d Distance) WithUncertainty(sigma float64) Distance {
+	return Distance(d.Quantity().WithUncertainty(sigma))
+}
+
+// Uncertainty returns the standard uncertainty of the pressure in
+// its unit, or zero if the uncertainty is not known.
+func (p Pressure) Uncertainty() float64 {
+	return p.uncertainty
+}
+
+// WithUncertainty returns the pressure with a standard uncertainty
+// in its unit.
+func (p Pressure) WithUncertainty(sigma float64) Pressure {
+	return Pressure(p.Quantity().WithUncertainty(sigma))
+}
+
+// Uncertainty returns the standard uncertainty of the velocity in
+// its unit, or zero if the uncertainty is not known.
+func (v Velocity) Uncertainty() float64 {
+	return v.uncertainty
+}
+
+// WithUncertainty returns the velocity with a standard uncertainty
+// in its unit.
+func (v Velocity) WithUncertainty(sigma float64) Velocity {
+	return Velocity(v.Quantity().WithUncertainty(sigma))
+}
+
+// Uncertainty returns the standard uncertainty of the humidity in
+// percent, or zero if the uncertainty is not known.
+func (h Humidity) Uncertainty() float64 {
+	return h.uncertainty
+}
+
+// WithUncertainty returns the humidity with a standard uncertainty
+// in percent.
+func (h Humidity) WithUncertainty(sigma float64) Humidity {
+	h.uncertainty = math.Abs(sigma)
+	return h
+}
+
+// Uncertainty returns the standard uncertainty of the altitude in
+// its unit, or zero if the uncertainty is not known.
+func (a Altitude) Uncertainty() float64 {
+	return a.uncertainty
+}
+
+// WithUncertainty returns the altitude with a standard uncertainty
+// in its unit.
+func (a Altitude) WithUncertainty(sigma float64) Altitude {
+	return Altitude(a.quantity().WithUncertainty(sigma))
+}
@@ -0,0 +1,239 @@
+package wx
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestUncertainty_To(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"celsius to fahrenheit", NewTemp(20, Celsius).WithUncertainty(0.5).ToF().Uncertainty(), 0.9},
+		{"celsius to kelvin", NewTemp(20, Celsius).WithUncertainty(0.5).ToK().Uncertainty(), 0.5},
+		{"statute miles to feet", NewDistance(3, StatuteMiles).WithUncertainty(0.25).ToFt().Uncertainty(), 1320},
+		{"negative distance", NewDistance(-10, Meters).WithUncertainty(1).ToFt().Uncertainty(), 1 / 0.3048},
+		{"inches of mercury to hectopascals", NewPressure(29.92, InHg).WithUncertainty(0.01).ToHPa().Uncertainty(), 0.338639},
+		{"knots to meters per second", NewVelocity(10, Kts).WithUncertainty(2).ToMps().Uncertainty(), 2 * 1852.0 / 3600},
+		// v = 0.836 B^1.5, so dv/dB = 1.254 B^0.5.
+		{"beaufort to meters per second", NewVelocity(4, Beaufort).WithUncertainty(0.5).ToMps().Uncertainty(), 0.5 * 1.254 * 2},
+		{"meters per second to beaufort", NewVelocity(0.836*8, Mps).WithUncertainty(1.254 * 2).To(Beaufort).Uncertainty(), 1},
+		{"no uncertainty", NewTemp(20, Celsius).ToF().Uncertainty(), 0},
+		{"negative sigma", NewVelocity(10, Kts).WithUncertainty(-2).Uncertainty(), 2},
+		{"humidity", NewHumidity(50).WithUncertainty(-3).Uncertainty(), 3},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tests.CloseEnough(tc.got, tc.want, 1e-6) {
+				t.Errorf("expected %v, got %v", tc.want, tc.got)
+			}
+		})
+	}
+}
+
+func TestUncertainty_Calm(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		v    Velocity
+		unit VelocityUnit
+	}{
+		{"meters per second to beaufort", NewVelocity(0, Mps).WithUncertainty(0.1), Beaufort},
+		{"beaufort to meters per second", NewVelocity(0, Beaufort).WithUncertainty(0.5), Mps},
+		{"beaufort to knots", NewVelocity(0, Beaufort).WithUncertainty(0.5), Kts},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.v.To(tc.unit).Uncertainty()
+			if math.IsNaN(got) || math.IsInf(got, 0) || got < 0 {
+				t.Errorf("expected a finite uncertainty, got %v", got)
+			}
+		})
+	}
+}
+
+func TestUncertainty_AddSub(t *testing.T) {
+	t.Parallel()
+
+	a := NewDistance(10, Feet).WithUncertainty(3)
+	b := NewDistance(1, Feet).WithUncertainty(4)
+
+	if got := a.Add(b); got.measurement != 11 || !tests.CloseEnough(got.Uncertainty(), 5, tests.Tolerance) {
+		t.Errorf("expected 11 ± 5 ft, got %v ± %v", got, got.Uncertainty())
+	}
+
+	if got := a.Sub(b); got.measurement != 9 || !tests.CloseEnough(got.Uncertainty(), 5, tests.Tolerance) {
+		t.Errorf("expected 9 ± 5 ft, got %v ± %v", got, got.Uncertainty())
+	}
+
+	// The uncertainty of the second distance is converted first.
+	if got := a.Add(NewDistance(1, Meters).WithUncertainty(4 * 0.3048)); !tests.CloseEnough(got.Uncertainty(), 5, 1e-9) {
+		t.Errorf("expected ± 5 ft, got %v", got.Uncertainty())
+	}
+
+	p := NewPressure(1000, HPa).WithUncertainty(0.3).Add(NewPressure(10, HPa).WithUncertainty(0.4))
+	if !tests.CloseEnough(p.Uncertainty(), 0.5, tests.Tolerance) {
+		t.Errorf("expected ± 0.5 hPa, got %v", p.Uncertainty())
+	}
+
+	p = NewPressure(1000, HPa).Sub(NewPressure(10, HPa))
+	if p.Uncertainty() != 0 {
+		t.Errorf("expected no uncertainty, got %v", p.Uncertainty())
+	}
+}
+
+func TestUncertainty_DewPoint(t *testing.T) {
+	t.Parallel()
+
+	// Independent estimate of the sensitivity to each input.
+	const h = 1e-3
+	dT := (DewPoint(NewTemp(25+h, Celsius), NewHumidity(60), Magnus).C() -
+		DewPoint(NewTemp(25-h, Celsius), NewHumidity(60), Magnus).C()) / (2 * h)
+	dRH := (DewPoint(NewTemp(25, Celsius), NewHumidity(60+h), Magnus).C() -
+		DewPoint(NewTemp(25, Celsius), NewHumidity(60-h), Magnus).C()) / (2 * h)
+	want := math.Hypot(dT*0.2, dRH*2)
+
+	got := DewPoint(NewTemp(25, Celsius).WithUncertainty(0.2), NewHumidity(60).WithUncertainty(2), Magnus)
+	if !tests.CloseEnough(got.Uncertainty(), want, 1e-5) {
+		t.Errorf("expected ± %v °C, got %v", want, got.Uncertainty())
+	}
+
+	// The result is in the unit of the temperature.
+	gotF := DewPoint(NewTemp(77, Fahrenheit).WithUncertainty(0.36), NewHumidity(60).WithUncertainty(2), Magnus)
+	if !tests.CloseEnough(gotF.Uncertainty(), want*1.8, 1e-4) {
+		t.Errorf("expected ± %v °F, got %v", want*1.8, gotF.Uncertainty())
+	}
+
+	rh := RelativeHumidity(NewTemp(25, Celsius).WithUncertainty(0.2), got.WithUncertainty(0), Magnus)
+	if !tests.CloseEnough(rh.Percent(), 60, 1e-6) || rh.Uncertainty() <= 0 {
+		t.Errorf("expected 60%% with an uncertainty, got %v ± %v", rh, rh.Uncertainty())
+	}
+
+	if got := DewPoint(NewTemp(25, Celsius), NewHumidity(60), Magnus); got.Uncertainty() != 0 {
+		t.Errorf("expected no uncertainty, got %v", got.Uncertainty())
+	}
+}
+
+func TestUncertainty_HeatIndex(t *testing.T) {
+	t.Parallel()
+
+	const h = 1e-3
+	hi := func(f, rh float64) float64 {
+		got, _ := HeatIndex(NewTemp(f, Fahrenheit), NewHumidity(rh))
+		return got.F()
+	}
+	dT := (hi(95+h, 50) - hi(95-h, 50)) / (2 * h)
+	dRH := (hi(95, 50+h) - hi(95, 50-h)) / (2 * h)
+	want := math.Hypot(dT*1, dRH*5)
+
+	got, err := HeatIndex(NewTemp(95, Fahrenheit).WithUncertainty(1), NewHumidity(50).WithUncertainty(5))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !tests.CloseEnough(got.Uncertainty(), want, 1e-5) {
+		t.Errorf("expected ± %v °F, got %v", want, got.Uncertainty())
+	}
+}
+
+func TestUncertainty_ApparentIndices(t *testing.T) {
+	t.Parallel()
+
+	const h = 1e-3
+
+	tt := []struct {
+		name  string
+		index func(t Temp, rh Humidity, v Velocity) (Temp, error)
+		temp  float64
+	}{
+		{"wind chill", func(t Temp, _ Humidity, v Velocity) (Temp, error) { return WindChill(t, v) }, -10},
+		{"humidex", func(t Temp, rh Humidity, _ Velocity) (Temp, error) { return Humidex(t, rh) }, 30},
+		{"apparent temperature", ApparentTemp, 25},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			at := func(c, rh, mps float64) float64 {
+				got, _ := tc.index(NewTemp(c, Celsius), NewHumidity(rh), NewVelocity(mps, Mps))
+				return got.C()
+			}
+			dT := (at(tc.temp+h, 50, 5) - at(tc.temp-h, 50, 5)) / (2 * h)
+			dRH := (at(tc.temp, 50+h, 5) - at(tc.temp, 50-h, 5)) / (2 * h)
+			dV := (at(tc.temp, 50, 5+h) - at(tc.temp, 50, 5-h)) / (2 * h)
+			want := math.Sqrt(dT*dT*0.25 + dRH*dRH*25 + dV*dV)
+
+			got, err := tc.index(NewTemp(tc.temp, Celsius).WithUncertainty(0.5), NewHumidity(50).WithUncertainty(5), NewVelocity(5, Mps).WithUncertainty(1))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Uncertainty() == 0 || !tests.CloseEnough(got.Uncertainty(), want, 1e-5) {
+				t.Errorf("expected ± %v °C, got %v", want, got.Uncertainty())
+			}
+		})
+	}
+}
+
+func TestUncertainty_SeaLevelPressure(t *testing.T) {
+	t.Parallel()
+
+	station := NewPressure(840, HPa)
+	elevation := NewAltitude(1600, Meters)
+	temp := NewTemp(15, Celsius)
+	slp := SeaLevelPressure(station, elevation, temp)
+
+	// The reduction is linear in the station pressure.
+	got := SeaLevelPressure(station.WithUncertainty(0.5), elevation, temp)
+	if want := 0.5 * slp.HPa() / 840; !tests.CloseEnough(got.Uncertainty(), want, 1e-6) {
+		t.Errorf("expected ± %v hPa, got %v", want, got.Uncertainty())
+	}
+
+	// Elevation and temperature errors add to the uncertainty.
+	all := SeaLevelPressure(station.WithUncertainty(0.5), elevation.WithUncertainty(5), temp.WithUncertainty(1))
+	if all.Uncertainty() <= got.Uncertainty() {
+		t.Errorf("expected more than ± %v hPa, got %v", got.Uncertainty(), all.Uncertainty())
+	}
+
+	// Reducing back to the station recovers the station pressure
+	// uncertainty when only the pressure is uncertain.
+	back := StationPressureFromSeaLevel(got, elevation, temp)
+	if !tests.CloseEnough(back.Uncertainty(), 0.5, 1e-6) {
+		t.Errorf("expected ± 0.5 hPa, got %v", back.Uncertainty())
+	}
+
+	// The result is in the unit of the station pressure.
+	inHg := SeaLevelPressure(station.ToInHg(), elevation, temp.WithUncertainty(1))
+	if !tests.CloseEnough(inHg.ToHPa().Uncertainty(), SeaLevelPressure(station, elevation, temp.WithUncertainty(1)).Uncertainty(), 1e-6) {
+		t.Errorf("expected the same uncertainty in inHg, got %v", inHg.Uncertainty())
+	}
+}
+
+func TestUncertainty_JSON(t *testing.T) {
+	t.Parallel()
+
+	want := NewTemp(20, Celsius).WithUncertainty(0.25)
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(b) != `{"value":20,"unit":"°C","uncertainty":0.25}` {
+		t.Errorf("unexpected JSON %s", b)
+	}
+
+	var got Temp
+	if err := json.Unmarshal(b, &got); err != nil || got != want {
+		t.Errorf("expected %v, got %v (%v)", want, got, err)
+	}
+}
@@ -0,0 +1,144 @@
+package wx
+
+// UnitSystem is a set of preferred units for displaying
+// measurements, such as the units used in aviation or at sea.
+// Measurements are converted to a system with their Convert method.
+//
+// A custom system may set only some units. Convert leaves a
+// measurement unchanged if the unit for its dimension is not set.
+type UnitSystem struct {
+	// Temp is the unit of temperatures.
+	Temp TempUnit
+	// Distance is the unit of horizontal distances such as
+	// visibility.
+	Distance DistanceUnit
+	// Range is the unit of short horizontal distances such as
+	// runway visual range.
+	Range DistanceUnit
+	// Height is the unit of vertical distances such as cloud bases
+	// and elevations.
+	Height DistanceUnit
+	// Pressure is the unit of pressures.
+	Pressure PressureUnit
+	// Velocity is the unit of velocities.
+	Velocity VelocityUnit
+}
+
+// Unit systems.
+var (
+	// Metric uses °C, km, m, hPa and km/h.
+	Metric = UnitSystem{
+		Temp:     Celsius,
+		Distance: Kilometers,
+		Range:    Meters,
+		Height:   Meters,
+		Pressure: HPa,
+		Velocity: Kph,
+	}
+
+	// Imperial uses °F, statute miles, feet, inHg and mph.
+	Imperial = UnitSystem{
+		Temp:     Fahrenheit,
+		Distance: StatuteMiles,
+		Range:    Feet,
+		Height:   Feet,
+		Pressure: InHg,
+		Velocity: Mph,
+	}
+
+	// Aviation uses the units of North American aviation reports:
+	// °C, statute miles, feet, inHg and knots.
+	Aviation = UnitSystem{
+		Temp:     Celsius,
+		Distance: StatuteMiles,
+		Range:    Feet,
+		Height:   Feet,
+		Pressure: InHg,
+		Velocity: Kts,
+	}
+
+	// AviationICAO uses the units of ICAO aviation reports: °C,
+	// meters, feet for heights, hPa and knots.
+	AviationICAO = UnitSystem{
+		Temp:     Celsius,
+		Distance: Meters,
+		Range:    Meters,
+		Height:   Feet,
+		Pressure: HPa,
+		Velocity: Kts,
+	}
+
+	// Marine uses °C, nautical miles, meters, hPa and knots.
+	Marine = UnitSystem{
+		Temp:     Celsius,
+		Distance: NauticalMiles,
+		Range:    Meters,
+		Height:   Meters,
+		Pressure: HPa,
+		Velocity: Kts,
+	}
+
+	// SI uses kelvin, meters, pascals and meters per second.
+	SI = UnitSystem{
+		Temp:     Kelvin,
+		Distance: Meters,
+		Range:    Meters,
+		Height:   Meters,
+		Pressure: Pa,
+		Velocity: Mps,
+	}
+)
+
+// convert converts a quantity to the unit, keeping it invalid if it
+// was invalid. The quantity is unchanged if either unit is not
+// registered, such as an unset unit of a custom system.
+func convert[D Dimension](q Quantity[D], unit D) Quantity[D] {
+	if _, ok := unit.definition(); !ok {
+		return q
+	}
+
+	if _, ok := q.unit.definition(); !ok {
+		return q
+	}
+
+	c := q.To(unit)
+	c.valid = c.valid && q.valid
+
+	return c
+}
+
+// Convert converts the temperature to the unit of the system.
+func (t Temp) Convert(s UnitSystem) Temp {
+	return Temp(convert(t.Quantity(), s.Temp))
+}
+
+// Convert converts the distance to the horizontal distance unit of
+// the system. Use To with the Range or Height unit of the system for
+// other distances.
+func (d Distance) Convert(s UnitSystem) Distance {
+	return Distance(convert(d.Quantity(), s.Distance))
+}
+
+// Convert converts the altitude to the height unit of the system.
+func (a Altitude) Convert(s UnitSystem) Altitude {
+	return Altitude(convert(a.quantity(), altitudeUnit{s.Height.distanceType}))
+}
+
+// Convert converts the pressure to the unit of the system.
+func (p Pressure) Convert(s UnitSystem) Pressure {
+	return Pressure(convert(p.Quantity(), s.Pressure))
+}
+
+// Convert converts the velocity to the unit of the system.
+func (v Velocity) Convert(s UnitSystem) Velocity {
+	return Velocity(convert(v.Quantity(), s.Velocity))
+}
+
+// Convert converts the speed and gust of the wind to the velocity
+// unit of the system.
+func (w Wind) Convert(s UnitSystem) Wind {
+	w.speed = w.speed.Convert(s)
+	w.gust = w.gust.Convert(s)
+
+	return w
+}
@@ -0,0 +1,100 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestUnitSystem_Convert(t *testing.T) {
+	t.Parallel()
+
+	temp := NewTemp(15, Celsius)
+	dist := NewDistance(10, Kilometers)
+	pres := NewPressure(1013.25, HPa)
+	vel := NewVelocity(10, Mps)
+
+	tt := []struct {
+		name   string
+		system UnitSystem
+		temp   TempUnit
+		dist   DistanceUnit
+		pres   PressureUnit
+		vel    VelocityUnit
+	}{
+		{"metric", Metric, Celsius, Kilometers, HPa, Kph},
+		{"imperial", Imperial, Fahrenheit, StatuteMiles, InHg, Mph},
+		{"aviation", Aviation, Celsius, StatuteMiles, InHg, Kts},
+		{"aviation icao", AviationICAO, Celsius, Meters, HPa, Kts},
+		{"marine", Marine, Celsius, NauticalMiles, HPa, Kts},
+		{"si", SI, Kelvin, Meters, Pa, Mps},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := temp.Convert(tc.system); got.Unit() != tc.temp || !tests.CloseEnough(got.C(), 15, tests.Tolerance) {
+				t.Errorf("expected 15 °C in %v, got %v", tc.temp, got)
+			}
+
+			if got := dist.Convert(tc.system); got.Unit() != tc.dist || !tests.CloseEnough(got.KM(), 10, tests.Tolerance) {
+				t.Errorf("expected 10 km in %v, got %v", tc.dist, got)
+			}
+
+			if got := pres.Convert(tc.system); got.Unit() != tc.pres || !tests.CloseEnough(got.HPa(), 1013.25, tests.Tolerance) {
+				t.Errorf("expected 1013.25 hPa in %v, got %v", tc.pres, got)
+			}
+
+			if got := vel.Convert(tc.system); got.Unit() != tc.vel || !tests.CloseEnough(got.Mps(), 10, tests.Tolerance) {
+				t.Errorf("expected 10 mps in %v, got %v", tc.vel, got)
+			}
+		})
+	}
+}
+
+func TestUnitSystem_ConvertInvalid(t *testing.T) {
+	t.Parallel()
+
+	// An unreported measurement stays invalid.
+	if got := (Velocity{}).Convert(Aviation); got.Valid() {
+		t.Errorf("expected invalid velocity, got %v", got)
+	}
+
+	// An invalid measurement is converted but stays invalid.
+	got := NewDistance(-100, Feet).Convert(SI)
+	if got.Valid() || got.Unit() != Meters || !tests.CloseEnough(got.measurement, -30.48, tests.Tolerance) {
+		t.Errorf("expected invalid -30.48 m, got %#v", got.Quantity())
+	}
+
+	// An unset unit leaves the measurement unchanged.
+	if got := NewVelocity(5, Kts).Convert(UnitSystem{}); got != NewVelocity(5, Kts) {
+		t.Errorf("expected 5 kts, got %v", got)
+	}
+
+	// A partially set custom system only converts the dimensions it
+	// sets.
+	custom := UnitSystem{Pressure: HPa}
+	if got := NewTemp(68, Fahrenheit).Convert(custom); got != NewTemp(68, Fahrenheit) {
+		t.Errorf("expected 68 °F, got %v", got)
+	}
+
+	if got := NewPressure(29.92, InHg).Convert(custom); got.Unit() != HPa || !tests.CloseEnough(got.HPa(), 1013.2, 0.1) {
+		t.Errorf("expected 1013.2 hPa, got %v", got)
+	}
+}
+
+func TestWind_Convert(t *testing.T) {
+	t.Parallel()
+
+	w := NewGustingWind(NewWindDirection(270), NewVelocity(10, Mps), NewVelocity(15, Mps)).Convert(Aviation)
+	if !w.Valid() || w.Speed().Unit() != Kts || w.Gust().Unit() != Kts {
+		t.Fatalf("expected wind in knots, got %v", w)
+	}
+
+	if !tests.CloseEnough(w.Gust().Mps(), 15, tests.Tolerance) {
+		t.Errorf("expected 15 mps gust, got %v", w.Gust())
+	}
+
+	if w := NewWind(NewWindDirection(90), NewVelocity(5, Kts)).Convert(SI); w.Gusting() {
+		t.Errorf("expected no gust, got %v", w)
+	}
+}
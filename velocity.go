@@ -1,6 +1,9 @@
 package wx
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // VelocityType represents a unit of velocity.
 type velocityType uint8
@@ -19,28 +22,18 @@ type velocityType uint8
 //		// This will be true.
 //	}
 const (
-	fps velocityType = iota + 1 // feet per second
-	kts                         // knots
-	kph                         // kilometers per hour
-	mph                         // miles per hour
-	mps                         // meters per second
+	fps      velocityType = iota + 1 // feet per second
+	kts                              // knots
+	kph                              // kilometers per hour
+	mph                              // miles per hour
+	mps                              // meters per second
+	mpm                              // meters per minute
+	beaufort                         // Beaufort scale
 )
 
 // String returns the string representation of the velocity unit.
 func (v velocityType) String() string {
-	switch v {
-	case fps:
-		return "fps"
-	case kts:
-		return "kts"
-	case kph:
-		return "kph"
-	case mph:
-		return "mph"
-	case mps:
-		return "mps"
-	}
-	return ""
+	return velocityUnits[v].symbol
 }
 
 // VelocityUnit is a unit of velocity (e.g., mph, kts, mps, fps, etc.)
@@ -53,6 +46,12 @@ func (v VelocityUnit) String() string {
 	return v.velocityType.String()
 }
 
+// definition returns the registry entry of the velocity unit.
+func (v VelocityUnit) definition() (unitDefinition, bool) {
+	def, ok := velocityUnits[v.velocityType]
+	return def, ok
+}
+
 // Velocity units.
 var (
 	// Fps is feet per second.
@@ -65,13 +64,44 @@ var (
 	Mph = VelocityUnit{mph}
 	// Mps is meters per second.
 	Mps = VelocityUnit{mps}
+	// Mpm is meters per minute.
+	Mpm = VelocityUnit{mpm}
+	// Beaufort is the Beaufort wind force scale. Conversions use the
+	// continuous WMO relation v = 0.836 B^(3/2) m/s, so round the
+	// measurement to get the Beaufort number.
+	Beaufort = VelocityUnit{beaufort}
 )
 
+// beaufortFactor is the factor of the WMO Beaufort scale relation
+// in m/s.
+const beaufortFactor = 0.836
+
+// velocityUnits is the registry of velocity units. The base unit is
+// meters per second.
+var velocityUnits = map[velocityType]unitDefinition{
+	fps: {symbol: "fps", scale: 1 / feetPerMeter},
+	kts: {symbol: "kts", scale: feetPerNauticalMile / feetPerMeter / 3600},
+	kph: {symbol: "kph", scale: 1000.0 / 3600.0},
+	mph: {symbol: "mph", scale: feetPerStatuteMile / feetPerMeter / 3600},
+	mps: {symbol: "mps", scale: 1},
+	mpm: {symbol: "m/min", scale: 1.0 / 60.0},
+	beaufort: {
+		symbol: "Bft",
+		toBase: func(b float64) float64 {
+			return beaufortFactor * math.Pow(b, 1.5)
+		},
+		fromBase: func(v float64) float64 {
+			return math.Pow(v/beaufortFactor, 2.0/3.0)
+		},
+	},
+}
+
 // Velocity represents a velocity measurement.
-type Velocity struct {
-	measurement float64
-	unit        VelocityUnit
-	valid       bool
+type Velocity Quantity[VelocityUnit]
+
+// Quantity returns the velocity as a generic quantity.
+func (v Velocity) Quantity() Quantity[VelocityUnit] {
+	return Quantity[VelocityUnit](v)
 }
 
 // String returns the string representation of the velocity.
@@ -87,136 +117,79 @@ func (v Velocity) Valid() bool {
 	return v.valid
 }
 
+// Unit returns the unit of the velocity.
+func (v Velocity) Unit() VelocityUnit {
+	return v.unit
+}
+
 // NewVelocity creates a new Velocity value.
 func NewVelocity(measurement float64, unit VelocityUnit) Velocity {
-	switch unit.velocityType {
-	case fps:
-		unit = Fps
-	case kts:
-		unit = Kts
-	case kph:
-		unit = Kph
-	case mph:
-		unit = Mph
-	case mps:
-		unit = Mps
-	default:
-		return Velocity{valid: false}
-	}
+	return Velocity(NewQuantity(measurement, unit))
+}
 
-	return Velocity{
-		measurement: measurement,
-		unit:        unit,
-		valid:       measurement >= 0}
+// NewVelocityE creates a new velocity like NewVelocity, but returns an error
+// describing why the velocity is invalid, such as a RangeError for a
+// negative velocity.
+func NewVelocityE(measurement float64, unit VelocityUnit) (Velocity, error) {
+	return NewVelocity(measurement, unit), checkQuantity(measurement, unit, "velocity")
 }
 
 // Fps returns the velocity in feet per second.
 func (v Velocity) Fps() float64 {
-	switch v.unit.velocityType {
-	case fps:
-		return v.measurement
-	case kts:
-		return v.measurement * feetPerNauticalMile / 3600
-	case kph:
-		return v.measurement * feetPerMeter * 1000 / 3600
-	case mph:
-		return v.measurement * feetPerStatuteMile / 3600
-	case mps:
-		return v.measurement * feetPerMeter
-	}
-	return 0
+	return v.In(Fps)
 }
 
 // Kph returns the velocity in kilometers per hour.
 func (v Velocity) Kph() float64 {
-	switch v.unit.velocityType {
-	case fps:
-		return v.measurement * feetPerMeter * 1000 / 3600
-	case kts:
-		return v.measurement * feetPerNauticalMile / feetPerMeter / 1000
-	case kph:
-		return v.measurement
-	case mph:
-		return v.measurement * feetPerStatuteMile / 3600 / feetPerMeter * 1000
-	case mps:
-		return v.measurement * 3600 / 1000
-	}
-	return 0
+	return v.In(Kph)
 }
 
 // Kts returns the velocity in knots.
 func (v Velocity) Kts() float64 {
-	switch v.unit.velocityType {
-	case fps:
-		return v.measurement * 3600 / feetPerNauticalMile
-	case kts:
-		return v.measurement
-	case kph:
-		return v.measurement * feetPerMeter * 1000 / feetPerNauticalMile
-	case mph:
-		return v.measurement * feetPerStatuteMile / feetPerNauticalMile
-	case mps:
-		return v.measurement * feetPerMeter / feetPerNauticalMile
-	}
-	return 0
+	return v.In(Kts)
 }
 
 // Mph returns the velocity in miles per hour.
 func (v Velocity) Mph() float64 {
-	switch v.unit.velocityType {
-	case fps:
-		return v.measurement * 3600 / feetPerStatuteMile
-	case kts:
-		return v.measurement * feetPerNauticalMile / feetPerStatuteMile
-	case kph:
-		return v.measurement * feetPerMeter * 1000 / feetPerStatuteMile
-	case mph:
-		return v.measurement
-	case mps:
-		return v.measurement * feetPerMeter * 3600 / feetPerStatuteMile
-	}
-	return 0
+	return v.In(Mph)
 }
 
 // Mps returns the velocity in meters per second.
 func (v Velocity) Mps() float64 {
-	switch v.unit.velocityType {
-	case fps:
-		return v.measurement / feetPerMeter
-	case kts:
-		return v.measurement * feetPerNauticalMile / feetPerMeter / 3600
-	case kph:
-		return v.measurement * 1000 / 3600
-	case mph:
-		return v.measurement * feetPerStatuteMile / feetPerMeter / 3600
-	case mps:
-		return v.measurement
-	}
-
-	return 0
+	return v.In(Mps)
 }
 
 // ToFps converts the velocity to feet per second.
 func (v Velocity) ToFps() Velocity {
-	return NewVelocity(v.Fps(), Fps)
+	return v.To(Fps)
 }
 
 // ToKts converts the velocity to knots.
 func (v Velocity) ToKts() Velocity {
-	return NewVelocity(v.Kts(), Kts)
+	return v.To(Kts)
 }
 
 // ToKph converts the velocity to kilometers per hour.
 func (v Velocity) ToKph() Velocity {
-	return NewVelocity(v.Kph(), Kph)
+	return v.To(Kph)
 }
 
 // ToMph converts the velocity to miles per hour.
 func (v Velocity) ToMph() Velocity {
-	return NewVelocity(v.Mph(), Mph)
+	return v.To(Mph)
 }
 
 // ToMps converts the velocity to meters per second.
 func (v Velocity) ToMps() Velocity {
-	return NewVelocity(v.Mps(), Mps)
+	return v.To(Mps)
+}
+
+// In returns the velocity in the specified unit.
+func (v Velocity) In(unit VelocityUnit) float64 {
+	return v.Quantity().In(unit)
+}
+
+// To converts the velocity to the specified unit.
+func (v Velocity) To(unit VelocityUnit) Velocity {
+	return Velocity(v.Quantity().To(unit))
 }
@@ -3,6 +3,8 @@ package wx
 import (
 	"math"
 	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
 )
 
 func TestVelocityType_String(t *testing.T) {
@@ -109,7 +111,7 @@ func TestVelocity_Fps(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			velocity := NewVelocity(tc.measurement, tc.unit)
-			if got := velocity.Fps(); got != tc.want {
+			if got := velocity.Fps(); !tests.CloseEnough(got, tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", got, tc.want)
 			}
 		})
@@ -125,10 +127,10 @@ func TestVelocity_Kph(t *testing.T) {
 		unit        VelocityUnit
 		want        float64
 	}{
-		{"fps", 1, Fps, feetPerMeter * 1000 / 3600},
+		{"fps", 1, Fps, 1 / feetPerMeter * 3600 / 1000},
 		{"kts", 1, Kts, feetPerNauticalMile / feetPerMeter / 1000},
 		{"kph", 1, Kph, 1},
-		{"mph", 1, Mph, feetPerStatuteMile / 3600 / feetPerMeter * 1000},
+		{"mph", 1, Mph, feetPerStatuteMile / feetPerMeter / 1000},
 		{"mps", 1, Mps, 3600.0 / 1000.0},
 	}
 
@@ -158,13 +160,13 @@ func TestVelocity_Kts(t *testing.T) {
 		{"kts", 1, Kts, 1},
 		{"kph", 1, Kph, feetPerMeter * 1000 / feetPerNauticalMile},
 		{"mph", 1, Mph, feetPerStatuteMile / feetPerNauticalMile},
-		{"mps", 1, Mps, feetPerMeter / feetPerNauticalMile},
+		{"mps", 1, Mps, feetPerMeter * 3600 / feetPerNauticalMile},
 	}
 
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			velocity := NewVelocity(tc.measurement, tc.unit)
-			if got := velocity.Kts(); got != tc.want {
+			if got := velocity.Kts(); !tests.CloseEnough(got, tc.want, tests.Tolerance) {
 				t.Errorf("got %v, want %v", got, tc.want)
 			}
 		})
@@ -190,7 +192,7 @@ func TestVelocity_Mph(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			velocity := NewVelocity(tc.measurement, tc.unit)
-			if got := velocity.Mph(); got != tc.want {
+			if got := velocity.Mph(); !tests.CloseEnough(got, tc.want, tests.Tolerance) {
 				// Check for floating point error.
 				if math.Abs(got-tc.want) > 0.0000001 {
 					t.Errorf("got %v, want %v", got, tc.want)
@@ -219,7 +221,7 @@ func TestVelocity_Mps(t *testing.T) {
 	for _, tc := range tt {
 		t.Run(tc.name, func(t *testing.T) {
 			velocity := NewVelocity(tc.measurement, tc.unit)
-			if got := velocity.Mps(); got != tc.want {
+			if got := velocity.Mps(); !tests.CloseEnough(got, tc.want, tests.Tolerance) {
 				// Check for floating point error.
 				if math.Abs(got-tc.want) > 0.0000001 {
 					t.Errorf("got %v, want %v", got, tc.want)
@@ -228,3 +230,28 @@ func TestVelocity_Mps(t *testing.T) {
 		})
 	}
 }
+
+func TestVelocity_Unit(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		unit VelocityUnit
+		want VelocityUnit
+	}{
+		{"fps", Fps, Fps},
+		{"kts", Kts, Kts},
+		{"kph", Kph, Kph},
+		{"mph", Mph, Mph},
+		{"mps", Mps, Mps},
+		{"invalid", VelocityUnit{velocityType(0)}, VelocityUnit{}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := NewVelocity(1, tc.unit).Unit(); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
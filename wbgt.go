@@ -0,0 +1,419 @@
+package wx
+
+import "math"
+
+// Constants of the Liljegren et al. (2008) WBGT model.
+const (
+	// Stefan-Boltzmann constant in W/(m²·K⁴).
+	stefanBoltzmann = 5.6696e-8
+	// Specific heat of dry air at constant pressure in J/(kg·K).
+	cpAir = 1003.5
+	// Molecular weights of dry air and water vapor in g/mol.
+	molarMassAir   = 28.97
+	molarMassWater = 18.015
+	// Gas constant of dry air in J/(kg·K).
+	gasConstantAir = 8314.34 / molarMassAir
+	// Prandtl number of air.
+	prandtl = cpAir / (cpAir + 1.25*gasConstantAir)
+
+	// Diameter, emissivity and albedo of the black globe.
+	globeDiameter   = 0.0508
+	globeEmissivity = 0.95
+	globeAlbedo     = 0.05
+
+	// Diameter, length, emissivity and albedo of the wick.
+	wickDiameter   = 0.007
+	wickLength     = 0.0254
+	wickEmissivity = 0.95
+	wickAlbedo     = 0.4
+
+	// Albedo and emissivity of the ground surface.
+	surfaceAlbedo     = 0.45
+	surfaceEmissivity = 0.999
+
+	// Solar constant in W/m².
+	solarConstant = 1367.0
+	// Minimum wind speed in meters per second of the model.
+	wbgtMinSpeed = 0.13
+	// Convergence criterion and maximum iterations of the globe and
+	// natural wet-bulb temperatures.
+	wbgtConvergence   = 0.02
+	wbgtMaxIterations = 50
+)
+
+// HeatStress holds the Wet Bulb Globe Temperature and the components
+// it is calculated from.
+type HeatStress struct {
+	// WBGT is the outdoor Wet Bulb Globe Temperature.
+	WBGT Temp
+	// NaturalWetBulb is the temperature of a naturally ventilated
+	// wet bulb exposed to radiation.
+	NaturalWetBulb Temp
+	// Globe is the temperature of a 2 inch black globe.
+	Globe Temp
+	// Air is the air temperature.
+	Air Temp
+}
+
+// Flag returns the heat stress flag condition for the WBGT.
+func (h HeatStress) Flag() HeatFlag {
+	if !h.WBGT.valid {
+		return HeatFlag{}
+	}
+
+	f := h.WBGT.F()
+	switch {
+	case f < 78:
+		return NoFlag
+	case f < 82:
+		return WhiteFlag
+	case f < 85:
+		return GreenFlag
+	case f < 88:
+		return YellowFlag
+	case f < 90:
+		return RedFlag
+	default:
+		return BlackFlag
+	}
+}
+
+// heatFlagType represents a heat stress flag condition.
+type heatFlagType uint8
+
+// Heat stress flag conditions.
+// The values start at 1 so the zero value is not a valid flag.
+const (
+	noFlag heatFlagType = iota + 1
+	whiteFlag
+	greenFlag
+	yellowFlag
+	redFlag
+	blackFlag
+)
+
+// String returns the string representation of the flag.
+func (f heatFlagType) String() string {
+	switch f {
+	case noFlag:
+		return "none"
+	case whiteFlag:
+		return "white"
+	case greenFlag:
+		return "green"
+	case yellowFlag:
+		return "yellow"
+	case redFlag:
+		return "red"
+	case blackFlag:
+		return "black"
+	}
+	return ""
+}
+
+// HeatFlag is a heat stress flag condition of the US Army
+// (TB MED 507, 2022) for a WBGT.
+type HeatFlag struct {
+	heatFlagType
+}
+
+// String returns the string representation of the flag.
+func (f HeatFlag) String() string {
+	return f.heatFlagType.String()
+}
+
+// Heat stress flag conditions.
+var (
+	// NoFlag is a WBGT below 78 °F.
+	NoFlag = HeatFlag{noFlag}
+	// WhiteFlag is a WBGT from 78 to 81.9 °F.
+	WhiteFlag = HeatFlag{whiteFlag}
+	// GreenFlag is a WBGT from 82 to 84.9 °F.
+	GreenFlag = HeatFlag{greenFlag}
+	// YellowFlag is a WBGT from 85 to 87.9 °F.
+	YellowFlag = HeatFlag{yellowFlag}
+	// RedFlag is a WBGT from 88 to 89.9 °F.
+	RedFlag = HeatFlag{redFlag}
+	// BlackFlag is a WBGT of 90 °F or more.
+	BlackFlag = HeatFlag{blackFlag}
+)
+
+// workloadType represents the intensity of physical work.
+type workloadType uint8
+
+// Workloads.
+// The values start at 1 so the zero value is not a valid workload.
+const (
+	easyWork workloadType = iota + 1
+	moderateWork
+	hardWork
+)
+
+// String returns the string representation of the workload.
+func (w workloadType) String() string {
+	switch w {
+	case easyWork:
+		return "easy"
+	case moderateWork:
+		return "moderate"
+	case hardWork:
+		return "hard"
+	}
+	return ""
+}
+
+// Workload is the intensity of physical work used for work/rest
+// guidance.
+type Workload struct {
+	workloadType
+}
+
+// String returns the string representation of the workload.
+func (w Workload) String() string {
+	return w.workloadType.String()
+}
+
+// Workloads.
+var (
+	// EasyWork is work at about 250 W, such as walking on a hard
+	// surface at 2.5 mph with a load of less than 30 lb.
+	EasyWork = Workload{easyWork}
+	// ModerateWork is work at about 425 W, such as walking on a hard
+	// surface at 3.5 mph with a load of less than 40 lb.
+	ModerateWork = Workload{moderateWork}
+	// HardWork is work at about 600 W, such as walking on a hard
+	// surface at 3.5 mph with a load of 40 lb or more.
+	HardWork = Workload{hardWork}
+)
+
+// workMinutes holds the minutes of work per hour for each flag and
+// workload of the TB MED 507 work/rest table.
+var workMinutes = map[heatFlagType][3]int{
+	noFlag:     {60, 60, 60},
+	whiteFlag:  {60, 60, 40},
+	greenFlag:  {60, 50, 30},
+	yellowFlag: {60, 40, 30},
+	redFlag:    {60, 30, 20},
+	blackFlag:  {50, 20, 10},
+}
+
+// WorkRest returns the recommended minutes of work and rest per hour
+// for a workload under the flag condition. An hour of work means
+// there is no limit. It returns zero for both if the flag or the
+// workload is invalid.
+func (f HeatFlag) WorkRest(w Workload) (work, rest int) {
+	minutes, ok := workMinutes[f.heatFlagType]
+	if !ok || w.workloadType == 0 {
+		return 0, 0
+	}
+
+	work = minutes[w.workloadType-1]
+	return work, 60 - work
+}
+
+// WBGT returns the outdoor Wet Bulb Globe Temperature for a
+// temperature, relative humidity, wind speed, station pressure,
+// global horizontal solar radiation in W/m² and cosine of the solar
+// zenith angle using the model of Liljegren et al. (2008).
+//
+// The wind speed should be measured at about 2 m, the height of the
+// globe. The fraction of direct radiation is estimated from the
+// ratio of the solar radiation to its top of the atmosphere value.
+// The results are in the same unit as t.
+func WBGT(t Temp, rh Humidity, v Velocity, station Pressure, solar, cosZenith float64) (HeatStress, error) {
+	if !t.valid || !rh.valid || !v.valid || !station.valid {
+		return HeatStress{}, NewWxErrKind(ErrInvalidMeasurement, "invalid temperature, humidity, wind speed or pressure", "wbgt")
+	}
+
+	if solar < 0 || math.IsNaN(solar) {
+		return HeatStress{}, NewWxErrKind(ErrOutOfDomain, "negative solar radiation", "wbgt")
+	}
+
+	if cosZenith < -1 || cosZenith > 1 || math.IsNaN(cosZenith) {
+		return HeatStress{}, NewWxErrKind(ErrOutOfDomain, "cosine of the zenith angle outside of [-1, 1]", "wbgt")
+	}
+
+	ta := t.K()
+	speed := math.Max(v.Mps(), wbgtMinSpeed)
+	hPa := station.HPa()
+	solar, fdir := solarParameters(solar, cosZenith)
+
+	tg, ok := globeTemp(ta, rh.Fraction(), hPa, speed, solar, fdir, cosZenith)
+	if !ok {
+		return HeatStress{}, NewWxErrKind(ErrOutOfDomain, "globe temperature did not converge", "wbgt")
+	}
+
+	tnwb, ok := naturalWetBulb(ta, rh.Fraction(), hPa, speed, solar, fdir, cosZenith)
+	if !ok {
+		return HeatStress{}, NewWxErrKind(ErrOutOfDomain, "natural wet-bulb temperature did not converge", "wbgt")
+	}
+
+	wbgt := 0.7*tnwb + 0.2*tg + 0.1*ta
+
+	return HeatStress{
+		WBGT:           NewTemp(wbgt, Kelvin).To(t.unit),
+		NaturalWetBulb: NewTemp(tnwb, Kelvin).To(t.unit),
+		Globe:          NewTemp(tg, Kelvin).To(t.unit),
+		Air:            t,
+	}, nil
+}
+
+// solarParameters limits the solar radiation to its top of the
+// atmosphere value and estimates the fraction of it that is direct.
+func solarParameters(solar, cosZenith float64) (float64, float64) {
+	// The sun is taken to be below the horizon within half a degree
+	// of it.
+	if cosZenith <= math.Cos(89.5*math.Pi/180) {
+		return 0, 0
+	}
+
+	toa := solarConstant * cosZenith
+	normalized := math.Min(solar/toa, 0.85)
+	if normalized <= 0 {
+		return 0, 0
+	}
+
+	fdir := math.Exp(3 - 1.34*normalized - 1.65/normalized)
+
+	return normalized * toa, math.Max(math.Min(fdir, 0.9), 0)
+}
+
+// globeTemp returns the temperature of the black globe in kelvin.
+func globeTemp(ta, rh, hPa, speed, solar, fdir, cosZenith float64) (float64, bool) {
+	emis := atmosphericEmissivity(ta, rh)
+
+	var radiation float64
+	if cosZenith > 0 {
+		radiation = solar / (2 * globeEmissivity * stefanBoltzmann) * (1 - globeAlbedo) *
+			(fdir*(1/(2*cosZenith)-1) + 1 + surfaceAlbedo)
+	}
+
+	prev := ta
+	for i := 0; i < wbgtMaxIterations; i++ {
+		tref := (prev + ta) / 2
+		h := sphereHeatTransfer(globeDiameter, tref, hPa, speed)
+
+		next := math.Pow(0.5*(emis*math.Pow(ta, 4)+surfaceEmissivity*math.Pow(ta, 4))-
+			h/(globeEmissivity*stefanBoltzmann)*(prev-ta)+radiation, 0.25)
+
+		if math.Abs(next-prev) < wbgtConvergence {
+			return next, true
+		}
+
+		prev = 0.9*prev + 0.1*next
+	}
+
+	return 0, false
+}
+
+// naturalWetBulb returns the natural wet-bulb temperature in kelvin.
+func naturalWetBulb(ta, rh, hPa, speed, solar, fdir, cosZenith float64) (float64, bool) {
+	emis := atmosphericEmissivity(ta, rh)
+	ea := rh * wbgtSaturationVaporPressure(ta)
+
+	var tanZenith float64
+	if cosZenith > 0 {
+		tanZenith = math.Tan(math.Acos(cosZenith))
+	}
+
+	// Solar radiation absorbed by the wick.
+	absorbed := (1 - wickAlbedo) * solar *
+		((1-fdir)*(1+0.25*wickDiameter/wickLength) +
+			fdir*(tanZenith/math.Pi+0.25*wickDiameter/wickLength) + surfaceAlbedo)
+
+	prev := ta
+	if ea > 0 {
+		prev = wbgtDewPoint(ea)
+	}
+	for i := 0; i < wbgtMaxIterations; i++ {
+		tref := (prev + ta) / 2
+		h := cylinderHeatTransfer(wickDiameter, tref, hPa, speed)
+
+		flux := stefanBoltzmann*wickEmissivity*(0.5*(emis*math.Pow(ta, 4)+surfaceEmissivity*math.Pow(ta, 4))-
+			math.Pow(prev, 4)) + absorbed
+
+		ew := wbgtSaturationVaporPressure(prev)
+		density := hPa * 100 / (gasConstantAir * tref)
+		schmidt := airViscosity(tref) / (density * vaporDiffusivity(tref, hPa))
+
+		next := ta - latentHeat(tref)/(cpAir*molarMassAir/molarMassWater)*
+			(ew-ea)/(hPa-ew)*math.Pow(prandtl/schmidt, 0.56) + flux/h
+
+		if math.Abs(next-prev) < wbgtConvergence {
+			return next, true
+		}
+
+		prev = 0.9*prev + 0.1*next
+	}
+
+	return 0, false
+}
+
+// sphereHeatTransfer returns the convective heat transfer coefficient
+// in W/(m²·K) of a sphere in air.
+func sphereHeatTransfer(diameter, t, hPa, speed float64) float64 {
+	density := hPa * 100 / (gasConstantAir * t)
+	re := speed * density * diameter / airViscosity(t)
+	nu := 2 + 0.6*math.Sqrt(re)*math.Pow(prandtl, 0.3333)
+
+	return nu * thermalConductivity(t) / diameter
+}
+
+// cylinderHeatTransfer returns the convective heat transfer
+// coefficient in W/(m²·K) of a long cylinder in cross flow.
+func cylinderHeatTransfer(diameter, t, hPa, speed float64) float64 {
+	density := hPa * 100 / (gasConstantAir * t)
+	re := speed * density * diameter / airViscosity(t)
+	nu := 0.281 * math.Pow(re, 0.6) * math.Pow(prandtl, 0.44)
+
+	return nu * thermalConductivity(t) / diameter
+}
+
+// airViscosity returns the dynamic viscosity of air in kg/(m·s).
+func airViscosity(t float64) float64 {
+	omega := (t/97-2.9)/0.4*-0.034 + 1.048
+	return 2.6693e-6 * math.Sqrt(molarMassAir*t) / (3.617 * 3.617 * omega)
+}
+
+// thermalConductivity returns the thermal conductivity of air in
+// W/(m·K).
+func thermalConductivity(t float64) float64 {
+	return (cpAir + 1.25*gasConstantAir) * airViscosity(t)
+}
+
+// vaporDiffusivity returns the diffusivity of water vapor in air in
+// m²/s.
+func vaporDiffusivity(t, hPa float64) float64 {
+	pcrit13 := math.Cbrt(36.4 * 218)
+	tcrit512 := math.Pow(132*647.3, 5.0/12)
+	tcrit12 := math.Sqrt(132 * 647.3)
+	mmix := math.Sqrt(1/molarMassAir + 1/molarMassWater)
+
+	return 3.640e-4 * math.Pow(t/tcrit12, 2.334) * pcrit13 * tcrit512 * mmix / (hPa / 1013.25) * 1e-4
+}
+
+// latentHeat returns the latent heat of vaporization of water in
+// J/kg.
+func latentHeat(t float64) float64 {
+	return (313.15-t)/30*-71100 + 2.4073e6
+}
+
+// atmosphericEmissivity returns the emissivity of the atmosphere for
+// a temperature in kelvin and relative humidity as a fraction.
+func atmosphericEmissivity(t, rh float64) float64 {
+	return 0.575 * math.Pow(rh*wbgtSaturationVaporPressure(t), 0.143)
+}
+
+// wbgtSaturationVaporPressure returns the saturation vapor pressure
+// over water in hPa for a temperature in kelvin using the Buck (1981)
+// formula with the enhancement factor of the model.
+func wbgtSaturationVaporPressure(t float64) float64 {
+	return 1.004 * 6.1121 * math.Exp(17.502*(t-273.15)/(t-32.18))
+}
+
+// wbgtDewPoint returns the dew point in kelvin for a vapor pressure in
+// hPa. It is the inverse of wbgtSaturationVaporPressure.
+func wbgtDewPoint(e float64) float64 {
+	z := math.Log(e / (1.004 * 6.1121))
+	return 273.15 + 240.97*z/(17.502-z)
+}
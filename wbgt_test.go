@@ -0,0 +1,125 @@
+package wx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestWBGT(t *testing.T) {
+	t.Parallel()
+
+	sea := NewPressure(1013.25, HPa)
+
+	tt := []struct {
+		name  string
+		temp  Temp
+		rh    Humidity
+		speed Velocity
+		solar float64
+		wbgt  float64
+		globe float64
+		flag  HeatFlag
+	}{
+		{"sunny", NewTemp(30, Celsius), NewHumidity(50), NewVelocity(1, Mps), 800, 30.18, 48.17, YellowFlag},
+		{"night", NewTemp(30, Celsius), NewHumidity(50), NewVelocity(1, Mps), 0, 24.15, 28.86, NoFlag},
+		{"hot and still", NewTemp(35, Celsius), NewHumidity(40), NewVelocity(0.5, Mps), 1000, 35.34, 59.74, BlackFlag},
+		{"below the minimum wind speed", NewTemp(35, Celsius), NewHumidity(40), NewVelocity(0, Mps), 1000, 40.14, 68.52, BlackFlag},
+		{"mild", NewTemp(20, Celsius), NewHumidity(80), NewVelocity(3, Mps), 300, 20.26, 25.45, NoFlag},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := WBGT(tc.temp, tc.rh, tc.speed, sea, tc.solar, 0.8)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tests.CloseEnough(got.WBGT.C(), tc.wbgt, 0.01) {
+				t.Errorf("expected a WBGT of %v °C, got %v", tc.wbgt, got.WBGT)
+			}
+
+			if !tests.CloseEnough(got.Globe.C(), tc.globe, 0.01) {
+				t.Errorf("expected a globe temperature of %v °C, got %v", tc.globe, got.Globe)
+			}
+
+			if nwb := got.NaturalWetBulb.C(); nwb < WetBulb(tc.temp, tc.rh, sea, HylandWexler).C()-0.5 || nwb > got.Globe.C() {
+				t.Errorf("unexpected natural wet bulb %v", got.NaturalWetBulb)
+			}
+
+			if got.Flag() != tc.flag {
+				t.Errorf("expected %v flag, got %v", tc.flag, got.Flag())
+			}
+		})
+	}
+
+	// The results are in the unit of the temperature.
+	got, err := WBGT(NewTemp(86, Fahrenheit), NewHumidity(50), NewVelocity(1, Mps).ToKts(), sea.ToInHg(), 800, 0.8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.WBGT.Unit() != Fahrenheit || !tests.CloseEnough(got.WBGT.C(), 30.18, 0.01) {
+		t.Errorf("expected 30.18 °C in Fahrenheit, got %v", got.WBGT)
+	}
+}
+
+func TestWBGT_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		temp      Temp
+		solar     float64
+		cosZenith float64
+		kind      error
+	}{
+		{"invalid temperature", Temp{}, 800, 0.8, ErrInvalidMeasurement},
+		{"negative radiation", NewTemp(30, Celsius), -1, 0.8, ErrOutOfDomain},
+		{"zenith out of range", NewTemp(30, Celsius), 800, 1.5, ErrOutOfDomain},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := WBGT(tc.temp, NewHumidity(50), NewVelocity(1, Mps), NewPressure(1013.25, HPa), tc.solar, tc.cosZenith)
+			if !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}
+
+func TestHeatFlag_WorkRest(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		flag HeatFlag
+		load Workload
+		work int
+		rest int
+	}{
+		{"no flag", NoFlag, HardWork, 60, 0},
+		{"white hard", WhiteFlag, HardWork, 40, 20},
+		{"green moderate", GreenFlag, ModerateWork, 50, 10},
+		{"red moderate", RedFlag, ModerateWork, 30, 30},
+		{"black easy", BlackFlag, EasyWork, 50, 10},
+		{"black hard", BlackFlag, HardWork, 10, 50},
+		{"invalid flag", HeatFlag{}, EasyWork, 0, 0},
+		{"invalid workload", RedFlag, Workload{}, 0, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			work, rest := tc.flag.WorkRest(tc.load)
+			if work != tc.work || rest != tc.rest {
+				t.Errorf("expected %d/%d, got %d/%d", tc.work, tc.rest, work, rest)
+			}
+		})
+	}
+
+	if got := (HeatStress{}).Flag(); got != (HeatFlag{}) {
+		t.Errorf("expected no flag for an invalid WBGT, got %v", got)
+	}
+}
@@ -0,0 +1,84 @@
+package wx
+
+import "math"
+
+// psychrometerCoefficient returns the psychrometer coefficient per
+// kelvin of an aspirated psychrometer (WMO, 2018) for a wet-bulb
+// temperature in Celsius.
+func psychrometerCoefficient(tw float64) float64 {
+	return 6.53e-4 * (1 + 9.44e-4*tw)
+}
+
+// WetBulb returns the psychrometric wet-bulb temperature for a
+// temperature, relative humidity and station pressure using the
+// given formulation. It is the temperature of an aspirated wet bulb,
+// found by solving the psychrometric equation
+//
+//	e = es(Tw) - A·p·(T - Tw)
+//
+// iteratively, so unlike WetBulbStull it accounts for the station
+// pressure. The result is in the same unit as t and is invalid if
+// any input is invalid.
+func WetBulb(t Temp, rh Humidity, station Pressure, f Formulation) Temp {
+	if !t.valid || !rh.valid || !station.valid || f.formulationType == 0 {
+		return Temp{}
+	}
+
+	wb := func(x []float64) float64 {
+		return wetBulbC(x[0], x[1], x[2], f)
+	}
+
+	tc, hPa := t.ToC(), station.ToHPa()
+	x := []float64{tc.measurement, rh.percent, hPa.measurement}
+	sigma := []float64{tc.uncertainty, rh.uncertainty, hPa.uncertainty}
+
+	return NewTemp(wb(x), Celsius).WithUncertainty(propagate(wb, x, sigma)).To(t.unit)
+}
+
+// wetBulbC solves the psychrometric equation by bisection between
+// the dew point and the air temperature for the wet-bulb temperature
+// in Celsius.
+func wetBulbC(c, rh, hPa float64, f Formulation) float64 {
+	e := rh / 100 * saturationVaporPressure(c, f, false)
+
+	// The residual increases with the wet-bulb temperature and is
+	// zero at the solution.
+	residual := func(tw float64) float64 {
+		return saturationVaporPressure(tw, f, false) - psychrometerCoefficient(tw)*hPa*(c-tw) - e
+	}
+
+	lo, hi := minDewPointC, c
+	if dp, ok := inverseSaturationVaporPressure(e, f, false); ok {
+		lo = dp
+	}
+
+	for i := 0; i < 100 && hi-lo > 1e-10; i++ {
+		mid := (lo + hi) / 2
+		if residual(mid) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2
+}
+
+// WetBulbStull returns the wet-bulb temperature for a temperature
+// and relative humidity using the empirical formula of Stull (2011).
+// It assumes a station pressure of 1013.25 hPa and is accurate to
+// within 1 °C for relative humidities between 5 and 99 percent and
+// temperatures between -20 and 50 °C. The result is in the same unit
+// as t and is invalid if either input is invalid.
+func WetBulbStull(t Temp, rh Humidity) Temp {
+	if !t.valid || !rh.valid {
+		return Temp{}
+	}
+
+	c, h := t.C(), rh.percent
+	tw := c*math.Atan(0.151977*math.Sqrt(h+8.313659)) +
+		math.Atan(c+h) - math.Atan(h-1.676331) +
+		0.00391838*math.Pow(h, 1.5)*math.Atan(0.023101*h) - 4.686035
+
+	return NewTemp(tw, Celsius).To(t.unit)
+}
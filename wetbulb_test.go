@@ -0,0 +1,56 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestWetBulb(t *testing.T) {
+	t.Parallel()
+
+	sea := NewPressure(1013.25, HPa)
+
+	tt := []struct {
+		name  string
+		got   Temp
+		want  float64
+		unit  TempUnit
+		valid bool
+	}{
+		{"sea level", WetBulb(NewTemp(20, Celsius), NewHumidity(50), sea, HylandWexler), 13.84, Celsius, true},
+		{"high altitude", WetBulb(NewTemp(20, Celsius), NewHumidity(50), NewPressure(700, HPa), HylandWexler), 12.97, Celsius, true},
+		{"saturated", WetBulb(NewTemp(30, Celsius), NewHumidity(100), sea, Magnus), 30, Celsius, true},
+		{"fahrenheit", WetBulb(NewTemp(68, Fahrenheit), NewHumidity(50), sea.ToInHg(), HylandWexler), 56.91, Fahrenheit, true},
+		{"stull", WetBulbStull(NewTemp(20, Celsius), NewHumidity(50)), 13.70, Celsius, true},
+		{"stull in kelvin", WetBulbStull(NewTemp(293.15, Kelvin), NewHumidity(50)), 286.85, Kelvin, true},
+		{"invalid temperature", WetBulb(Temp{}, NewHumidity(50), sea, Magnus), 0, Celsius, false},
+		{"invalid pressure", WetBulb(NewTemp(20, Celsius), NewHumidity(50), Pressure{}, Magnus), 0, Celsius, false},
+		{"invalid formulation", WetBulb(NewTemp(20, Celsius), NewHumidity(50), sea, Formulation{}), 0, Celsius, false},
+		{"stull invalid humidity", WetBulbStull(NewTemp(20, Celsius), Humidity{}), 0, Celsius, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.got.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, tc.got.Valid())
+			}
+
+			if tc.valid && (tc.got.Unit() != tc.unit || !tests.CloseEnough(tc.got.measurement, tc.want, 0.01)) {
+				t.Errorf("expected %v %v, got %v", tc.want, tc.unit, tc.got)
+			}
+		})
+	}
+
+	// The wet bulb lies between the dew point and the temperature.
+	temp, rh := NewTemp(25, Celsius), NewHumidity(40)
+	wb := WetBulb(temp, rh, sea, Buck)
+	if dp := DewPoint(temp, rh, Buck); wb.C() <= dp.C() || wb.C() >= temp.C() {
+		t.Errorf("expected a wet bulb between %v and %v, got %v", dp, temp, wb)
+	}
+
+	// The uncertainty of the inputs is propagated.
+	if got := WetBulb(temp.WithUncertainty(0.5), rh.WithUncertainty(2), sea, Buck); got.Uncertainty() <= 0 {
+		t.Errorf("expected an uncertainty, got %v", got.Uncertainty())
+	}
+}
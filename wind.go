@@ -0,0 +1,178 @@
+package wx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Wind represents a wind observation: the direction the wind is
+// coming from, its speed and an optional gust speed.
+//
+// A wind may be calm, when the speed is zero, or have a variable
+// direction, in which case it has a speed but no direction.
+type Wind struct {
+	direction WindDirection
+	speed     Velocity
+	gust      Velocity
+	variable  bool
+	valid     bool
+}
+
+// NewWind creates a new Wind from a direction and speed.
+// The wind is invalid if the speed is invalid.
+func NewWind(direction WindDirection, speed Velocity) Wind {
+	if !speed.valid {
+		return Wind{valid: false}
+	}
+
+	return Wind{
+		direction: direction,
+		speed:     speed,
+		valid:     true,
+	}
+}
+
+// NewGustingWind creates a new Wind from a direction, speed and
+// gust speed. The gust is converted to the unit of the speed. The
+// wind is invalid if either speed is invalid or the gust is less
+// than the speed.
+func NewGustingWind(direction WindDirection, speed, gust Velocity) Wind {
+	w := NewWind(direction, speed)
+	if !w.valid || !gust.valid {
+		return Wind{valid: false}
+	}
+
+	w.gust = gust.To(speed.unit)
+	if w.gust.measurement < speed.measurement {
+		return Wind{valid: false}
+	}
+
+	return w
+}
+
+// NewVariableWind creates a new Wind with a variable direction.
+// The wind is invalid if the speed is invalid.
+func NewVariableWind(speed Velocity) Wind {
+	w := NewWind(WindDirection{}, speed)
+	w.variable = w.valid
+
+	return w
+}
+
+// NewCalmWind creates a new calm Wind in the given unit.
+func NewCalmWind(unit VelocityUnit) Wind {
+	return NewWind(WindDirection{}, NewVelocity(0, unit))
+}
+
+// NewWindFromComponents creates a new Wind from its u (east-west)
+// and v (north-south) components in the given unit. The components
+// are positive when the wind blows towards the east and the north.
+// A wind with both components (nearly) zero is calm.
+func NewWindFromComponents(u, v float64, unit VelocityUnit) Wind {
+	speed := NewVelocity(math.Hypot(u, v), unit)
+	if !speed.valid {
+		return Wind{valid: false}
+	}
+
+	// Treat floating point residue, e.g. from adding opposite
+	// winds, as calm.
+	if speed.measurement < 1e-9 {
+		return NewCalmWind(unit)
+	}
+
+	deg := math.Atan2(-u, -v) * 180 / math.Pi
+
+	return NewWind(NewWindDirection(deg), speed)
+}
+
+// Valid returns true if the wind is valid.
+func (w Wind) Valid() bool {
+	return w.valid
+}
+
+// Direction returns the direction the wind is coming from.
+// Calm and variable winds have a zero direction.
+func (w Wind) Direction() WindDirection {
+	return w.direction
+}
+
+// Speed returns the wind speed.
+func (w Wind) Speed() Velocity {
+	return w.speed
+}
+
+// Gust returns the gust speed. It is invalid if the wind is not
+// gusting.
+func (w Wind) Gust() Velocity {
+	return w.gust
+}
+
+// Calm returns true if the wind speed is zero.
+func (w Wind) Calm() bool {
+	return w.valid && w.speed.measurement == 0
+}
+
+// Variable returns true if the wind direction is variable.
+func (w Wind) Variable() bool {
+	return w.variable
+}
+
+// Gusting returns true if the wind has a gust speed.
+func (w Wind) Gusting() bool {
+	return w.gust.valid
+}
+
+// Components returns the u (east-west) and v (north-south)
+// components of the wind in the given unit. The components are
+// positive when the wind blows towards the east and the north.
+//
+// ok is false if the wind or unit is invalid or the direction is
+// variable, since a variable wind has no direction to resolve.
+func (w Wind) Components(unit VelocityUnit) (u, v float64, ok bool) {
+	if !w.valid || w.variable {
+		return 0, 0, false
+	}
+
+	speed := w.speed.To(unit)
+	if !speed.valid {
+		return 0, 0, false
+	}
+
+	x, y := w.direction.UnitVector()
+
+	return speed.measurement * x, speed.measurement * y, true
+}
+
+// Add returns the vector sum of two winds in the unit of w.
+// Gusts are not carried over. The result is invalid if either wind
+// is invalid or variable.
+func (w Wind) Add(w2 Wind) Wind {
+	u1, v1, ok1 := w.Components(w.speed.unit)
+	u2, v2, ok2 := w2.Components(w.speed.unit)
+	if !ok1 || !ok2 {
+		return Wind{valid: false}
+	}
+
+	return NewWindFromComponents(u1+u2, v1+v2, w.speed.unit)
+}
+
+// String returns the string representation of the wind.
+func (w Wind) String() string {
+	switch {
+	case !w.valid:
+		return "invalid wind"
+	case w.Calm():
+		return "calm"
+	}
+
+	dir := "variable"
+	if !w.variable {
+		dir = fmt.Sprintf("%03.0f°", w.direction.degrees.degrees)
+	}
+
+	if w.gust.valid {
+		return fmt.Sprintf("%s at %s gusting %s", dir, w.speed, w.gust)
+	}
+
+	return fmt.Sprintf("%s at %s", dir, w.speed)
+}
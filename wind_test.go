@@ -0,0 +1,204 @@
+package wx
+
+import (
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestNewWind(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		wind     Wind
+		valid    bool
+		calm     bool
+		variable bool
+		gusting  bool
+		str      string
+	}{
+		{"steady", NewWind(NewWindDirection(270), NewVelocity(15, Kts)), true, false, false, false, "270° at 15.0 kts"},
+		{"gusting", NewGustingWind(NewWindDirection(90), NewVelocity(15, Kts), NewVelocity(25, Kts)), true, false, false, true, "090° at 15.0 kts gusting 25.0 kts"},
+		{"gust unit", NewGustingWind(NewWindDirection(90), NewVelocity(10, Mps), NewVelocity(72, Kph)), true, false, false, true, "090° at 10.0 mps gusting 20.0 mps"},
+		{"gust below speed", NewGustingWind(NewWindDirection(90), NewVelocity(15, Kts), NewVelocity(10, Kts)), false, false, false, false, "invalid wind"},
+		{"invalid gust", NewGustingWind(NewWindDirection(90), NewVelocity(15, Kts), Velocity{}), false, false, false, false, "invalid wind"},
+		{"variable", NewVariableWind(NewVelocity(3, Kts)), true, false, true, false, "variable at 3.0 kts"},
+		{"calm", NewCalmWind(Kts), true, true, false, false, "calm"},
+		{"invalid speed", NewWind(NewWindDirection(90), NewVelocity(-1, Kts)), false, false, false, false, "invalid wind"},
+		{"invalid variable", NewVariableWind(Velocity{}), false, false, false, false, "invalid wind"},
+		{"zero value", Wind{}, false, false, false, false, "invalid wind"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.wind.Valid() != tc.valid {
+				t.Errorf("expected valid %v, got %v", tc.valid, tc.wind.Valid())
+			}
+
+			if tc.wind.Calm() != tc.calm {
+				t.Errorf("expected calm %v, got %v", tc.calm, tc.wind.Calm())
+			}
+
+			if tc.wind.Variable() != tc.variable {
+				t.Errorf("expected variable %v, got %v", tc.variable, tc.wind.Variable())
+			}
+
+			if tc.wind.Gusting() != tc.gusting {
+				t.Errorf("expected gusting %v, got %v", tc.gusting, tc.wind.Gusting())
+			}
+
+			if tc.wind.String() != tc.str {
+				t.Errorf("expected %q, got %q", tc.str, tc.wind.String())
+			}
+		})
+	}
+}
+
+func TestWind_Components(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name string
+		wind Wind
+		unit VelocityUnit
+		u, v float64
+		ok   bool
+	}{
+		{"north", NewWind(NewWindDirection(360), NewVelocity(10, Kts)), Kts, 0, -10, true},
+		{"east", NewWind(NewWindDirection(90), NewVelocity(10, Kts)), Kts, -10, 0, true},
+		{"south", NewWind(NewWindDirection(180), NewVelocity(10, Mps)), Mps, 0, 10, true},
+		{"west in knots", NewWind(NewWindDirection(270), NewVelocity(10, Mph)), Kts, 8.6898, 0, true},
+		{"southwest", NewWind(NewWindDirection(225), NewVelocity(10, Mph)), Mph, 7.0711, 7.0711, true},
+		{"calm", NewCalmWind(Kts), Mps, 0, 0, true},
+		{"variable", NewVariableWind(NewVelocity(3, Kts)), Kts, 0, 0, false},
+		{"invalid unit", NewWind(NewWindDirection(90), NewVelocity(10, Kts)), VelocityUnit{}, 0, 0, false},
+		{"invalid wind", Wind{}, Kts, 0, 0, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			u, v, ok := tc.wind.Components(tc.unit)
+			if ok != tc.ok {
+				t.Fatalf("expected ok %v, got %v", tc.ok, ok)
+			}
+
+			if !tests.CloseEnough(u, tc.u, 1e-4) || !tests.CloseEnough(v, tc.v, 1e-4) {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tc.u, tc.v, u, v)
+			}
+		})
+	}
+}
+
+func TestNewWindFromComponents(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		u, v      float64
+		unit      VelocityUnit
+		direction float64
+		speed     float64
+		calm      bool
+		valid     bool
+	}{
+		{"from north", 0, -10, Kts, 0, 10, false, true},
+		{"from east", -10, 0, Kts, 90, 10, false, true},
+		{"from south", 0, 10, Kts, 180, 10, false, true},
+		{"from west", 10, 0, Kts, 270, 10, false, true},
+		{"from southwest", 3, 4, Mps, 216.8699, 5, false, true},
+		{"calm", 0, 0, Mps, 0, 0, true, true},
+		{"invalid unit", 1, 1, VelocityUnit{}, 0, 0, false, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewWindFromComponents(tc.u, tc.v, tc.unit)
+			if w.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, w.Valid())
+			}
+
+			if w.Calm() != tc.calm {
+				t.Errorf("expected calm %v, got %v", tc.calm, w.Calm())
+			}
+
+			if !tc.valid {
+				return
+			}
+
+			if !tests.CloseEnough(w.Direction().Degrees().Degrees(), tc.direction, 1e-4) {
+				t.Errorf("expected direction %v, got %v", tc.direction, w.Direction().Degrees())
+			}
+
+			if w.Speed().Unit() != tc.unit || !tests.CloseEnough(w.Speed().measurement, tc.speed, 1e-9) {
+				t.Errorf("expected speed %v %v, got %v", tc.speed, tc.unit, w.Speed())
+			}
+		})
+	}
+}
+
+func TestWind_ComponentsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for deg := 0.0; deg < 360; deg += 15 {
+		w := NewWind(NewWindDirection(deg), NewVelocity(12, Kts))
+		u, v, _ := w.Components(Kts)
+
+		got := NewWindFromComponents(u, v, Kts)
+		if !tests.CloseEnough(got.Direction().Degrees().Degrees(), deg, 1e-9) ||
+			!tests.CloseEnough(got.Speed().Kts(), 12, 1e-9) {
+			t.Errorf("%v°: round trip gave %v", deg, got)
+		}
+	}
+}
+
+func TestWind_Add(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name      string
+		w1, w2    Wind
+		direction float64
+		speed     float64
+		calm      bool
+		valid     bool
+	}{
+		{"same direction", NewWind(NewWindDirection(90), NewVelocity(10, Kts)), NewWind(NewWindDirection(90), NewVelocity(5, Kts)), 90, 15, false, true},
+		{"opposite", NewWind(NewWindDirection(90), NewVelocity(10, Kts)), NewWind(NewWindDirection(270), NewVelocity(10, Kts)), 0, 0, true, true},
+		{"across north", NewWind(NewWindDirection(350), NewVelocity(10, Kts)), NewWind(NewWindDirection(10), NewVelocity(10, Kts)), 0, 19.6962, false, true},
+		{"mixed units", NewWind(NewWindDirection(180), NewVelocity(10, Kts)), NewWind(NewWindDirection(180), NewVelocity(feetPerNauticalMile/feetPerStatuteMile, Mph)), 180, 11, false, true},
+		{"calm", NewWind(NewWindDirection(45), NewVelocity(10, Kts)), NewCalmWind(Mps), 45, 10, false, true},
+		{"variable", NewWind(NewWindDirection(45), NewVelocity(10, Kts)), NewVariableWind(NewVelocity(3, Kts)), 0, 0, false, false},
+		{"invalid", Wind{}, NewWind(NewWindDirection(45), NewVelocity(10, Kts)), 0, 0, false, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := tc.w1.Add(tc.w2)
+			if w.Valid() != tc.valid {
+				t.Fatalf("expected valid %v, got %v", tc.valid, w.Valid())
+			}
+
+			if w.Calm() != tc.calm {
+				t.Errorf("expected calm %v, got %v", tc.calm, w.Calm())
+			}
+
+			if !tc.valid || tc.calm {
+				return
+			}
+
+			if w.Speed().Unit() != Kts {
+				t.Errorf("expected unit %v, got %v", Kts, w.Speed().Unit())
+			}
+
+			got := w.Direction().Degrees().Degrees()
+			if !tests.CloseEnough(got, tc.direction, 1e-6) && !tests.CloseEnough(got, tc.direction+360, 1e-6) {
+				t.Errorf("expected direction %v, got %v", tc.direction, got)
+			}
+
+			if !tests.CloseEnough(w.Speed().Kts(), tc.speed, 1e-4) {
+				t.Errorf("expected speed %v, got %v", tc.speed, w.Speed().Kts())
+			}
+		})
+	}
+}
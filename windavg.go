@@ -0,0 +1,101 @@
+package wx
+
+import "math"
+
+// WindAverage is the average of a series of wind observations.
+type WindAverage struct {
+	// VectorDirection is the direction of the resultant vector
+	// mean wind.
+	VectorDirection WindDirection
+	// VectorSpeed is the speed of the resultant vector mean wind.
+	VectorSpeed Velocity
+	// ScalarSpeed is the arithmetic mean of the wind speeds.
+	ScalarSpeed Velocity
+	// UnitVectorDirection is the mean direction computed from the
+	// unit vectors of the observations, so it is not weighted by
+	// the wind speed.
+	UnitVectorDirection WindDirection
+	// DirectionStdDev is the Yamartino estimate of the standard
+	// deviation of the wind direction in degrees.
+	DirectionStdDev float64
+	// Steadiness is the ratio of the vector mean speed to the
+	// scalar mean speed, between 0 and 1. A steady wind from a
+	// constant direction has a steadiness of 1.
+	Steadiness float64
+}
+
+// AverageWind returns the vector and scalar averages of a series of
+// wind observations, with the speeds in the given unit.
+//
+// Calm and variable observations count towards the mean speeds but
+// have no direction, so they contribute nothing to the vector sum
+// and are left out of the unit vector direction and its standard
+// deviation. If no observation has a direction the average is calm.
+//
+// An error is returned if there are no observations or any
+// observation or the unit is invalid.
+func AverageWind(winds []Wind, unit VelocityUnit) (WindAverage, error) {
+	if len(winds) == 0 {
+		return WindAverage{}, NewWxErrKind(ErrInvalidMeasurement, "no observations", "wind average")
+	}
+
+	if NewVelocity(0, unit).unit.velocityType == 0 {
+		return WindAverage{}, NewWxErrKind(ErrInvalidUnit, "invalid unit", "wind average")
+	}
+
+	var sumU, sumV, sumSpeed, sumSin, sumCos float64
+	var directional int
+	for _, w := range winds {
+		if !w.valid {
+			return WindAverage{}, NewWxErrKind(ErrInvalidMeasurement, "invalid observation", "wind average")
+		}
+
+		sumSpeed += w.speed.To(unit).measurement
+
+		if w.variable || w.Calm() {
+			continue
+		}
+
+		u, v, _ := w.Components(unit)
+		sumU += u
+		sumV += v
+
+		x, y := w.direction.UnitVector()
+		sumSin += x
+		sumCos += y
+		directional++
+	}
+
+	n := float64(len(winds))
+	vector := NewWindFromComponents(sumU/n, sumV/n, unit)
+
+	avg := WindAverage{
+		VectorDirection: vector.direction,
+		VectorSpeed:     vector.speed,
+		ScalarSpeed:     NewVelocity(sumSpeed/n, unit),
+	}
+
+	if avg.ScalarSpeed.measurement > 0 {
+		avg.Steadiness = avg.VectorSpeed.measurement / avg.ScalarSpeed.measurement
+	}
+
+	if directional == 0 {
+		return avg, nil
+	}
+
+	sa, ca := sumSin/float64(directional), sumCos/float64(directional)
+	avg.UnitVectorDirection = NewWindFromComponents(sa, ca, unit).direction
+	avg.DirectionStdDev = yamartino(sa, ca)
+
+	return avg, nil
+}
+
+// yamartino returns the Yamartino (1984) estimate of the standard
+// deviation of the wind direction in degrees from the mean sine and
+// cosine of the directions.
+func yamartino(sa, ca float64) float64 {
+	eps := math.Sqrt(math.Max(0, 1-(sa*sa+ca*ca)))
+	sigma := math.Asin(eps) * (1 + (2/math.Sqrt(3)-1)*eps*eps*eps)
+
+	return sigma * 180 / math.Pi
+}
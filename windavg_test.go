@@ -0,0 +1,96 @@
+package wx
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/go-wx/wx/internal/tests"
+)
+
+func TestAverageWind(t *testing.T) {
+	t.Parallel()
+
+	wind := func(deg, kts float64) Wind {
+		return NewWind(NewWindDirection(deg), NewVelocity(kts, Kts))
+	}
+
+	tt := []struct {
+		name          string
+		winds         []Wind
+		vectorDir     float64
+		vectorSpeed   float64
+		scalarSpeed   float64
+		unitVectorDir float64
+		stdDev        float64
+		steadiness    float64
+	}{
+		{"across north", []Wind{wind(350, 10), wind(10, 10)}, 0, 9.8481, 10, 0, 10.0081, 0.98481},
+		{"constant direction", []Wind{wind(270, 5), wind(270, 15)}, 270, 10, 10, 270, 0, 1},
+		{"speed weighted", []Wind{wind(90, 10), wind(180, 1)}, 95.7106, 5.0249, 5.5, 135, 47.4613, 0.91362},
+		{"with calm", []Wind{wind(90, 10), NewCalmWind(Kts)}, 90, 5, 5, 90, 0, 1},
+		{"with variable", []Wind{wind(90, 10), NewVariableWind(NewVelocity(4, Kts))}, 90, 5, 7, 90, 0, 0.71429},
+		{"mixed units", []Wind{wind(180, 10), NewWind(NewWindDirection(180), NewVelocity(10*feetPerNauticalMile/feetPerStatuteMile, Mph))}, 180, 10, 10, 180, 0, 1},
+		{"all calm", []Wind{NewCalmWind(Kts), NewCalmWind(Mps)}, 0, 0, 0, 0, 0, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			avg, err := AverageWind(tc.winds, Kts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			checks := []struct {
+				name      string
+				got, want float64
+			}{
+				{"vector direction", avg.VectorDirection.Degrees().Degrees(), tc.vectorDir},
+				{"vector speed", avg.VectorSpeed.Kts(), tc.vectorSpeed},
+				{"scalar speed", avg.ScalarSpeed.Kts(), tc.scalarSpeed},
+				{"unit vector direction", avg.UnitVectorDirection.Degrees().Degrees(), tc.unitVectorDir},
+				{"direction std dev", avg.DirectionStdDev, tc.stdDev},
+				{"steadiness", avg.Steadiness, tc.steadiness},
+			}
+
+			for _, c := range checks {
+				// Directions near north may come back as just under 360.
+				got := c.got
+				if math.Abs(got-360-c.want) < math.Abs(got-c.want) {
+					got -= 360
+				}
+
+				if !tests.CloseEnough(got, c.want, 1e-4) {
+					t.Errorf("%s: expected %v, got %v", c.name, c.want, c.got)
+				}
+			}
+
+			if avg.VectorSpeed.Unit() != Kts || avg.ScalarSpeed.Unit() != Kts {
+				t.Errorf("expected speeds in %v, got %v and %v", Kts, avg.VectorSpeed.Unit(), avg.ScalarSpeed.Unit())
+			}
+		})
+	}
+}
+
+func TestAverageWind_Errors(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name  string
+		winds []Wind
+		unit  VelocityUnit
+		kind  error
+	}{
+		{"no observations", nil, Kts, ErrInvalidMeasurement},
+		{"invalid observation", []Wind{NewCalmWind(Kts), {}}, Kts, ErrInvalidMeasurement},
+		{"invalid unit", []Wind{NewCalmWind(Kts)}, VelocityUnit{}, ErrInvalidUnit},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := AverageWind(tc.winds, tc.unit); !errors.Is(err, tc.kind) {
+				t.Errorf("expected %v, got %v", tc.kind, err)
+			}
+		})
+	}
+}